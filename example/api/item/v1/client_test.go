@@ -0,0 +1,82 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// itemService 记录服务端绑定得到的请求
+type itemService struct {
+	list   *ListItemsReq
+	create *CreateItemReq
+}
+
+func (s *itemService) ListItems(ctx context.Context, req *ListItemsReq) (*ListItemsResp, error) {
+	s.list = req
+	return &ListItemsResp{Items: []string{"a"}}, nil
+}
+
+func (s *itemService) CreateItem(ctx context.Context, req *CreateItemReq) (*CreateItemResp, error) {
+	s.create = req
+	return &CreateItemResp{ID: 1, Name: req.Name}, nil
+}
+
+// newTestClient 启动使用生成的处理器的服务端，返回连接到该服务端的生成的客户端
+func newTestClient(t *testing.T) (*ItemServiceHTTPClient, *itemService) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	service := &itemService{}
+	engine := gin.New()
+	NewItemServiceHandler(log.DefaultLogger, service, nil).RegisterRoutes(engine)
+	server := httptest.NewServer(engine)
+	t.Cleanup(server.Close)
+
+	cc, err := khttp.NewClient(context.Background(), khttp.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = cc.Close() })
+	return NewItemServiceHTTPClient(cc), service
+}
+
+func TestHTTPClientSendsHeaders(t *testing.T) {
+	client, service := newTestClient(t)
+	ctx := context.Background()
+
+	list, err := client.ListItems(ctx, &ListItemsReq{Token: "secret", Tags: []string{"x", "y"}, Page: 2})
+	if err != nil {
+		t.Fatalf("ListItems: %v", err)
+	}
+	if !reflect.DeepEqual(list.Items, []string{"a"}) {
+		t.Errorf("ListItems 响应 = %v", list.Items)
+	}
+	want := &ListItemsReq{Token: "secret", Tags: []string{"x", "y"}, Page: 2}
+	if !reflect.DeepEqual(service.list, want) {
+		t.Errorf("服务端收到 %+v，期望 %+v", service.list, want)
+	}
+
+	created, err := client.CreateItem(ctx, &CreateItemReq{Token: "secret", Name: "book"})
+	if err != nil {
+		t.Fatalf("CreateItem: %v", err)
+	}
+	if created.Name != "book" || service.create.Token != "secret" {
+		t.Errorf("CreateItem 响应 = %+v，服务端收到 %+v", created, service.create)
+	}
+}
+
+func TestHTTPClientMissingRequiredHeader(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	_, err := client.ListItems(context.Background(), &ListItemsReq{})
+	if se := errors.FromError(err); se == nil || se.Code != http.StatusBadRequest {
+		t.Fatalf("缺少 X-Token 时的错误 = %v，期望 400", err)
+	}
+}
@@ -4,16 +4,22 @@ package v1
 
 import (
 	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	kgin "github.com/go-kratos/gin"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/log"
 	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
-	"net/http"
 )
 
-// Middleware 中间件接口
-type Middleware interface {
+// UserServiceMiddleware 中间件接口
+type UserServiceMiddleware interface {
 	Auth() gin.HandlerFunc
 	Logging() gin.HandlerFunc
 	Admin() gin.HandlerFunc
@@ -22,43 +28,46 @@ type Middleware interface {
 // UserServiceHandler UserService 处理器
 type UserServiceHandler struct {
 	log         *log.Helper
-	middleware  Middleware
+	middleware  UserServiceMiddleware
 	userService UserService
 	translator  ut.Translator
+	options     handlerOptions
 }
 
 // NewUserServiceHandler 创建 UserService 处理器
-func NewUserServiceHandler(logger log.Logger, middleware Middleware, userService UserService, translator ut.Translator) *UserServiceHandler {
+func NewUserServiceHandler(logger log.Logger, middleware UserServiceMiddleware, userService UserService, translator ut.Translator, opts ...HandlerOption) *UserServiceHandler {
 	return &UserServiceHandler{
 		log:         log.NewHelper(logger),
 		middleware:  middleware,
 		userService: userService,
 		translator:  translator,
+		options:     newHandlerOptions(opts),
 	}
 }
 
 // RegisterRoutes 注册路由
 func (h *UserServiceHandler) RegisterRoutes(r *gin.Engine) {
+	authMiddleware := h.middleware.Auth()
+	loggingMiddleware := h.middleware.Logging()
+	adminMiddleware := h.middleware.Admin()
+
 	PrefixGroup := r.Group("/v1")
 	{
-		PrefixGroup.Use(h.middleware.Auth())
-		PrefixGroup.Use(h.middleware.Logging())
-		PrefixGroup.GET("/users/:id", h.GetUser)
-		PrefixGroup.POST("/users", h.CreateUser)
-		PrefixGroup.PUT("/users/:id", h.UpdateUser)
-		PrefixGroup.DELETE("/users/:id", h.DeleteUser)
+		PrefixGroup.GET("/users/:id", authMiddleware, loggingMiddleware, h.GetUser)
+		PrefixGroup.POST("/users", authMiddleware, loggingMiddleware, h.CreateUser)
+		PrefixGroup.PUT("/users/:id", authMiddleware, loggingMiddleware, h.UpdateUser)
+		PrefixGroup.DELETE("/users/:id", authMiddleware, loggingMiddleware, h.DeleteUser)
 
 		AdminGroup := PrefixGroup.Group("/admin")
 		{
-			AdminGroup.Use(h.middleware.Admin())
-			AdminGroup.GET("/users", h.GetAllUsers)
-			AdminGroup.DELETE("/users", h.BulkDeleteUsers)
+			AdminGroup.GET("/users", authMiddleware, loggingMiddleware, adminMiddleware, h.GetAllUsers)
+			AdminGroup.DELETE("/users", authMiddleware, loggingMiddleware, adminMiddleware, h.BulkDeleteUsers)
 		}
 
 		PublicGroup := PrefixGroup.Group("/public")
 		{
-			PublicGroup.GET("/users/:id", h.GetPublicUser)
-			PublicGroup.GET("/users/search", h.SearchUsers)
+			PublicGroup.GET("/users/:id", loggingMiddleware, h.GetPublicUser)
+			PublicGroup.GET("/users/search", loggingMiddleware, h.SearchUsers)
 		}
 	}
 }
@@ -66,195 +75,404 @@ func (h *UserServiceHandler) RegisterRoutes(r *gin.Engine) {
 // GetUser
 func (h *UserServiceHandler) GetUser(c *gin.Context) {
 	req := &UserReq{}
-	if err := c.ShouldBind(req); err != nil {
-		err = translateValidationError(err, h.translator)
+	err := bindURI(c, req)
+	if err == nil {
+		err = c.ShouldBindQuery(req)
+	}
+	if err != nil {
+		err = translateValidationError(err, req, h.options.requestTranslator(c, h.translator))
 		h.log.Errorw("Struct", "UserServiceHandler", "method", "GetUser", "error", err)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": err.Error(),
-		})
+		h.options.bindErrorEncoder(c, err)
 		return
 	}
 
 	ctx := c.Request.Context()
 	resp, err := h.userService.GetUser(ctx, req)
 	if err != nil {
-		kgin.Error(c, err)
+		h.options.errorEncoder(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, resp)
+	h.options.responseEncoder(c, http.StatusOK, resp)
 }
 
 // CreateUser
 func (h *UserServiceHandler) CreateUser(c *gin.Context) {
 	req := &CreateUserReq{}
 	if err := c.ShouldBind(req); err != nil {
-		err = translateValidationError(err, h.translator)
+		err = translateValidationError(err, req, h.options.requestTranslator(c, h.translator))
 		h.log.Errorw("Struct", "UserServiceHandler", "method", "CreateUser", "error", err)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": err.Error(),
-		})
+		h.options.bindErrorEncoder(c, err)
 		return
 	}
 
 	ctx := c.Request.Context()
 	resp, err := h.userService.CreateUser(ctx, req)
 	if err != nil {
-		kgin.Error(c, err)
+		h.options.errorEncoder(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, resp)
+	h.options.responseEncoder(c, http.StatusCreated, resp)
 }
 
 // UpdateUser
 func (h *UserServiceHandler) UpdateUser(c *gin.Context) {
 	req := &UpdateUserReq{}
-	if err := c.ShouldBind(req); err != nil {
-		err = translateValidationError(err, h.translator)
+	err := bindURI(c, req)
+	if err == nil {
+		err = c.ShouldBind(req)
+	}
+	if err != nil {
+		err = translateValidationError(err, req, h.options.requestTranslator(c, h.translator))
 		h.log.Errorw("Struct", "UserServiceHandler", "method", "UpdateUser", "error", err)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": err.Error(),
-		})
+		h.options.bindErrorEncoder(c, err)
 		return
 	}
 
 	ctx := c.Request.Context()
 	resp, err := h.userService.UpdateUser(ctx, req)
 	if err != nil {
-		kgin.Error(c, err)
+		h.options.errorEncoder(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, resp)
+	h.options.responseEncoder(c, http.StatusOK, resp)
 }
 
 // DeleteUser
 func (h *UserServiceHandler) DeleteUser(c *gin.Context) {
 	req := &UserReq{}
-	if err := c.ShouldBind(req); err != nil {
-		err = translateValidationError(err, h.translator)
+	err := bindURI(c, req)
+	if err == nil {
+		err = c.ShouldBind(req)
+	}
+	if err != nil {
+		err = translateValidationError(err, req, h.options.requestTranslator(c, h.translator))
 		h.log.Errorw("Struct", "UserServiceHandler", "method", "DeleteUser", "error", err)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": err.Error(),
-		})
+		h.options.bindErrorEncoder(c, err)
 		return
 	}
 
 	ctx := c.Request.Context()
 	resp, err := h.userService.DeleteUser(ctx, req)
 	if err != nil {
-		kgin.Error(c, err)
+		h.options.errorEncoder(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, resp)
+	h.options.responseEncoder(c, http.StatusOK, resp)
 }
 
 // GetAllUsers
 func (h *UserServiceHandler) GetAllUsers(c *gin.Context) {
 	req := &UserReq{}
-	if err := c.ShouldBind(req); err != nil {
-		err = translateValidationError(err, h.translator)
+	if err := c.ShouldBindQuery(req); err != nil {
+		err = translateValidationError(err, req, h.options.requestTranslator(c, h.translator))
 		h.log.Errorw("Struct", "UserServiceHandler", "method", "GetAllUsers", "error", err)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": err.Error(),
-		})
+		h.options.bindErrorEncoder(c, err)
 		return
 	}
 
 	ctx := c.Request.Context()
 	resp, err := h.userService.GetAllUsers(ctx, req)
 	if err != nil {
-		kgin.Error(c, err)
+		h.options.errorEncoder(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, resp)
+	h.options.responseEncoder(c, http.StatusOK, resp)
 }
 
 // BulkDeleteUsers
 func (h *UserServiceHandler) BulkDeleteUsers(c *gin.Context) {
 	req := &UserReq{}
 	if err := c.ShouldBind(req); err != nil {
-		err = translateValidationError(err, h.translator)
+		err = translateValidationError(err, req, h.options.requestTranslator(c, h.translator))
 		h.log.Errorw("Struct", "UserServiceHandler", "method", "BulkDeleteUsers", "error", err)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": err.Error(),
-		})
+		h.options.bindErrorEncoder(c, err)
 		return
 	}
 
 	ctx := c.Request.Context()
 	resp, err := h.userService.BulkDeleteUsers(ctx, req)
 	if err != nil {
-		kgin.Error(c, err)
+		h.options.errorEncoder(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, resp)
+	h.options.responseEncoder(c, http.StatusOK, resp)
 }
 
 // GetPublicUser
 func (h *UserServiceHandler) GetPublicUser(c *gin.Context) {
 	req := &UserReq{}
-	if err := c.ShouldBind(req); err != nil {
-		err = translateValidationError(err, h.translator)
+	err := bindURI(c, req)
+	if err == nil {
+		err = c.ShouldBindQuery(req)
+	}
+	if err != nil {
+		err = translateValidationError(err, req, h.options.requestTranslator(c, h.translator))
 		h.log.Errorw("Struct", "UserServiceHandler", "method", "GetPublicUser", "error", err)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": err.Error(),
-		})
+		h.options.bindErrorEncoder(c, err)
 		return
 	}
 
 	ctx := c.Request.Context()
 	resp, err := h.userService.GetPublicUser(ctx, req)
 	if err != nil {
-		kgin.Error(c, err)
+		h.options.errorEncoder(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, resp)
+	h.options.responseEncoder(c, http.StatusOK, resp)
 }
 
 // SearchUsers
 func (h *UserServiceHandler) SearchUsers(c *gin.Context) {
 	req := &UserReq{}
-	if err := c.ShouldBind(req); err != nil {
-		err = translateValidationError(err, h.translator)
+	if err := c.ShouldBindQuery(req); err != nil {
+		err = translateValidationError(err, req, h.options.requestTranslator(c, h.translator))
 		h.log.Errorw("Struct", "UserServiceHandler", "method", "SearchUsers", "error", err)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": err.Error(),
-		})
+		h.options.bindErrorEncoder(c, err)
 		return
 	}
 
 	ctx := c.Request.Context()
 	resp, err := h.userService.SearchUsers(ctx, req)
 	if err != nil {
-		kgin.Error(c, err)
+		h.options.errorEncoder(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, resp)
+	h.options.responseEncoder(c, http.StatusOK, resp)
+}
+
+// ErrorEncoder 将服务方法返回的错误写入响应
+type ErrorEncoder func(c *gin.Context, err error)
+
+// BindErrorEncoder 将请求绑定或校验失败的错误写入响应，err 已经过翻译
+type BindErrorEncoder func(c *gin.Context, err error)
+
+// ResponseEncoder 将服务方法返回的响应写入，status 为方法的成功状态码，没有响应类型时 resp 为 nil
+type ResponseEncoder func(c *gin.Context, status int, resp interface{})
+
+// HandlerOption 处理器选项
+type HandlerOption func(*handlerOptions)
+
+// handlerOptions 处理器写入响应和错误的方式，以及校验错误翻译器的选择方式
+type handlerOptions struct {
+	errorEncoder        ErrorEncoder
+	bindErrorEncoder    BindErrorEncoder
+	responseEncoder     ResponseEncoder
+	universalTranslator *ut.UniversalTranslator
+	localeQuery         string
+}
+
+// WithErrorEncoder 设置服务错误的编码方式，默认为 DefaultErrorEncoder
+func WithErrorEncoder(encoder ErrorEncoder) HandlerOption {
+	return func(o *handlerOptions) {
+		o.errorEncoder = encoder
+	}
+}
+
+// WithBindErrorEncoder 设置请求绑定错误的编码方式，默认为 DefaultBindErrorEncoder
+func WithBindErrorEncoder(encoder BindErrorEncoder) HandlerOption {
+	return func(o *handlerOptions) {
+		o.bindErrorEncoder = encoder
+	}
+}
+
+// WithResponseEncoder 设置成功响应的编码方式，默认为 DefaultResponseEncoder
+func WithResponseEncoder(encoder ResponseEncoder) HandlerOption {
+	return func(o *handlerOptions) {
+		o.responseEncoder = encoder
+	}
+}
+
+// WithUniversalTranslator 按请求的语言选择校验错误的翻译器
+// 依次匹配查询参数和 Accept-Language 中的语言，都不支持时使用构造函数传入的翻译器，其为 nil 时使用 uni 的默认语言
+func WithUniversalTranslator(uni *ut.UniversalTranslator) HandlerOption {
+	return func(o *handlerOptions) {
+		o.universalTranslator = uni
+	}
+}
+
+// WithLocaleQuery 设置指定语言的查询参数名，默认为 DefaultLocaleQuery，为空时只使用 Accept-Language
+func WithLocaleQuery(name string) HandlerOption {
+	return func(o *handlerOptions) {
+		o.localeQuery = name
+	}
+}
+
+// newHandlerOptions 在默认编码方式的基础上应用处理器选项
+func newHandlerOptions(opts []HandlerOption) handlerOptions {
+	o := handlerOptions{
+		errorEncoder:     DefaultErrorEncoder,
+		bindErrorEncoder: DefaultBindErrorEncoder,
+		responseEncoder:  DefaultResponseEncoder,
+		localeQuery:      DefaultLocaleQuery,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
 }
 
-// translateValidationError 翻译验证错误
-func translateValidationError(err error, translator ut.Translator) error {
-	if translator == nil {
+// requestTranslator 返回当前请求使用的翻译器，未设置 WithUniversalTranslator 时为 fallback
+func (o handlerOptions) requestTranslator(c *gin.Context, fallback ut.Translator) ut.Translator {
+	if o.universalTranslator == nil {
+		return fallback
+	}
+	if trans, found := o.universalTranslator.FindTranslator(requestLocales(c, o.localeQuery)...); found {
+		return trans
+	}
+	if fallback != nil {
+		return fallback
+	}
+	return o.universalTranslator.GetFallback()
+}
+
+// DefaultErrorEncoder 将服务错误编码为 Kratos 错误
+func DefaultErrorEncoder(c *gin.Context, err error) {
+	kgin.Error(c, err)
+}
+
+// DefaultBindErrorEncoder 以 400 和 {"message": ..., "fields": {...}} 写入请求绑定错误
+// fields 以 json 名称为键，只有校验失败时存在
+func DefaultBindErrorEncoder(c *gin.Context, err error) {
+	body := gin.H{"message": err.Error()}
+	var se *kerrors.Error
+	if errors.As(err, &se) {
+		body["message"] = se.Message
+		if len(se.Metadata) > 0 {
+			body["fields"] = se.Metadata
+		}
+	}
+	c.JSON(http.StatusBadRequest, body)
+}
+
+// DefaultResponseEncoder 以 JSON 写入成功响应，没有响应类型或状态码为 204 时只写入状态码
+func DefaultResponseEncoder(c *gin.Context, status int, resp interface{}) {
+	if resp == nil || status == http.StatusNoContent {
+		c.Status(status)
+		return
+	}
+	c.JSON(status, resp)
+}
+
+// bindURI 绑定路径参数，按 uri 标签匹配，没有对应 uri 标签时按 json 名称匹配，不做校验
+func bindURI(c *gin.Context, req interface{}) error {
+	params := make(map[string][]string, len(c.Params))
+	for _, param := range c.Params {
+		params[param.Key] = []string{param.Value}
+	}
+	if err := binding.MapFormWithTag(req, params, "uri"); err != nil {
 		return err
 	}
+	return binding.MapFormWithTag(req, params, "json")
+}
+
+// bindQuery 按 form 标签绑定查询参数，不做校验
+func bindQuery(c *gin.Context, req interface{}) error {
+	return binding.MapFormWithTag(req, c.Request.URL.Query(), "form")
+}
+
+// bindHeader 按 header 标签绑定请求头，不做校验
+func bindHeader(c *gin.Context, req interface{}, keys ...string) error {
+	headers := make(map[string][]string, len(keys))
+	for _, key := range keys {
+		if values := c.Request.Header.Values(key); len(values) > 0 {
+			headers[key] = values
+		}
+	}
+	return binding.MapFormWithTag(req, headers, "header")
+}
+
+// ValidationReason 请求校验失败时 Kratos 错误的 reason
+const ValidationReason = "VALIDATION_FAILED"
 
+// translateValidationError 将校验错误转换为 Kratos 的 BadRequest 错误
+// metadata 以字段的 json 名称为键（嵌套字段以 . 连接）保存各字段的错误信息，设置了翻译器时错误信息经过翻译
+func translateValidationError(err error, req interface{}, translator ut.Translator) error {
 	var errs validator.ValidationErrors
-	if errors.As(err, &errs) {
-		// 错误信息通过翻译器获取
-		translations := errs.Translate(translator)
-		var msg string
-		for _, e := range translations {
-			msg += e + ";"
+	if !errors.As(err, &errs) {
+		return err
+	}
+
+	fields := make(map[string]string, len(errs))
+	messages := make([]string, 0, len(errs))
+	for _, fe := range errs {
+		key := validationFieldKey(reflect.TypeOf(req), fe.StructNamespace())
+		msg := fmt.Sprintf("Field validation for '%s' failed on the '%s' tag", key, fe.Tag())
+		// 没有注册翻译的规则 Translate 返回 fe.Error()，此时保留上面的错误信息
+		if translator != nil {
+			if translated := fe.Translate(translator); translated != fe.Error() {
+				msg = translated
+			}
 		}
-		return errors.New(msg)
+		if _, ok := fields[key]; !ok {
+			fields[key] = msg
+		}
+		messages = append(messages, msg)
 	}
+	return kerrors.BadRequest(ValidationReason, strings.Join(messages, ";")).WithMetadata(fields)
+}
 
-	return err
+// validationFieldKey 将校验器的结构体命名空间（如 CreateUserReq.Address.City）转换为 json 字段路径（如 address.city）
+// 没有 json 名称的字段依次使用 uri、form、header 标签，嵌入的结构体不占用路径
+func validationFieldKey(t reflect.Type, namespace string) string {
+	segments := strings.Split(namespace, ".")[1:]
+	path := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		name, index := segment, ""
+		if i := strings.IndexByte(segment, '['); i >= 0 {
+			name, index = segment[:i], segment[i:]
+		}
+
+		for t != nil && t.Kind() != reflect.Struct {
+			switch t.Kind() {
+			case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+				t = t.Elem()
+			default:
+				t = nil
+			}
+		}
+		if t == nil {
+			path = append(path, segment)
+			continue
+		}
+		field, ok := t.FieldByName(name)
+		if !ok {
+			t = nil
+			path = append(path, segment)
+			continue
+		}
+		t = field.Type
+
+		key := tagName(field.Tag.Get("json"))
+		if key == "" && field.Anonymous {
+			continue
+		}
+		for _, tag := range []string{"uri", "form", "header"} {
+			if key != "" {
+				break
+			}
+			key = tagName(field.Tag.Get(tag))
+		}
+		if key == "" {
+			key = field.Name
+		}
+		path = append(path, key+index)
+	}
+	return strings.Join(path, ".")
+}
+
+// tagName 返回结构体标签中的名称部分，"-" 视为没有名称
+func tagName(tag string) string {
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "-" {
+		return ""
+	}
+	return name
 }
@@ -0,0 +1,84 @@
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// newLocaleContext 创建带有查询参数和 Accept-Language 的请求上下文
+func newLocaleContext(query, acceptLanguage string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/users/1"+query, nil)
+	if acceptLanguage != "" {
+		c.Request.Header.Set("Accept-Language", acceptLanguage)
+	}
+	return c
+}
+
+func TestRequestLocales(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		acceptLanguage string
+		want           []string
+	}{
+		{name: "没有指定语言"},
+		{name: "地区语言后追加基础语言", acceptLanguage: "zh-CN", want: []string{"zh_CN", "zh"}},
+		{name: "按权重排序", acceptLanguage: "fr;q=0.3, en;q=0.8, zh-TW", want: []string{"zh_TW", "zh", "en", "fr"}},
+		{name: "权重相同时保持原顺序", acceptLanguage: "en;q=0.5, zh;q=0.5", want: []string{"en", "zh"}},
+		{name: "忽略 * 和权重为 0 的语言", acceptLanguage: "*, de;q=0, en", want: []string{"en"}},
+		{name: "无效的权重按 1 处理", acceptLanguage: "en;q=0.5, fr;q=abc", want: []string{"fr", "en"}},
+		{name: "查询参数优先", query: "?lang=en-US", acceptLanguage: "zh", want: []string{"en_US", "en", "zh"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := requestLocales(newLocaleContext(tt.query, tt.acceptLanguage), DefaultLocaleQuery)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("requestLocales() = %q，期望 %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestTranslator(t *testing.T) {
+	uni, err := NewUniversalTranslator(validator.New(), "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	zh, _ := uni.GetTranslator("zh")
+
+	tests := []struct {
+		name           string
+		opts           []HandlerOption
+		query          string
+		acceptLanguage string
+		fallback       bool // 是否传入处理器的默认翻译器 zh
+		want           string
+	}{
+		{name: "选择第一个支持的语言", opts: []HandlerOption{WithUniversalTranslator(uni)}, acceptLanguage: "fr, en;q=0.5, zh;q=0.4", want: "en"},
+		{name: "地区语言匹配基础语言", opts: []HandlerOption{WithUniversalTranslator(uni)}, acceptLanguage: "zh-CN", want: "zh"},
+		{name: "查询参数优先", opts: []HandlerOption{WithUniversalTranslator(uni)}, query: "?lang=zh", acceptLanguage: "en", want: "zh"},
+		{name: "关闭查询参数", opts: []HandlerOption{WithUniversalTranslator(uni), WithLocaleQuery("")}, query: "?lang=zh", acceptLanguage: "en", want: "en"},
+		{name: "都不支持时使用处理器的翻译器", opts: []HandlerOption{WithUniversalTranslator(uni)}, acceptLanguage: "fr", fallback: true, want: "zh"},
+		{name: "都不支持且没有处理器的翻译器时使用默认语言", opts: []HandlerOption{WithUniversalTranslator(uni)}, acceptLanguage: "fr", want: "en"},
+		{name: "没有设置 WithUniversalTranslator", acceptLanguage: "en", fallback: true, want: "zh"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newHandlerOptions(tt.opts)
+			var fallback = zh
+			if !tt.fallback {
+				fallback = nil
+			}
+			trans := o.requestTranslator(newLocaleContext(tt.query, tt.acceptLanguage), fallback)
+			if trans == nil || trans.Locale() != tt.want {
+				t.Errorf("requestTranslator() = %v，期望 %s", trans, tt.want)
+			}
+		})
+	}
+}
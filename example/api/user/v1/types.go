@@ -1,7 +1,47 @@
 // Code generated by kratosgin. DO NOT EDIT.
+// source: user.gin
 
 package v1
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// 用户状态
+type UserStatus int
+
+const (
+	UserStatusActive   UserStatus = 1 // 正常
+	UserStatusDisabled UserStatus = 2 // 禁用
+)
+
+// String 返回枚举取值的名称，未定义的取值返回 UserStatus(数值)
+func (e UserStatus) String() string {
+	switch e {
+	case UserStatusActive:
+		return "Active"
+	case UserStatusDisabled:
+		return "Disabled"
+	}
+	return fmt.Sprintf("UserStatus(%d)", int(e))
+}
+
+// MarshalJSON 按基础类型编码枚举
+func (e UserStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int(e))
+}
+
+// UnmarshalJSON 按基础类型解码枚举，取值是否有效由 binding 标签中的 oneof 规则校验
+func (e *UserStatus) UnmarshalJSON(data []byte) error {
+	value := int(*e)
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("UserStatus 必须是整数: %w", err)
+	}
+	*e = UserStatus(value)
+	return nil
+}
+
 // UserReq 结构体
 type UserReq struct {
 	ID    int    `json:"id" binding:"required,min=1"`
@@ -17,12 +57,13 @@ type Base struct {
 
 // UserResp 结构体
 type UserResp struct {
-	Base             // 嵌入式字段
-	ID        int    `json:"id"`
-	Name      string `json:"name"`
-	Email     string `json:"email"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	Base                 // 嵌入式字段
+	ID        int        `json:"id"`
+	Name      string     `json:"name"`
+	Email     string     `json:"email"`
+	CreatedAt string     `json:"created_at"`
+	UpdatedAt string     `json:"updated_at"`
+	Status    UserStatus `json:"status" binding:"omitempty,oneof=1 2"`
 }
 
 // CreateUserReq 结构体
@@ -30,6 +71,7 @@ type CreateUserReq struct {
 	Name     string `json:"name" binding:"required"`
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required,min=6"`
+	Phone    string `json:"phone" binding:"omitempty,phone"`
 }
 
 // CreateUserResp 结构体
@@ -42,9 +84,10 @@ type CreateUserResp struct {
 
 // UpdateUserReq 结构体
 type UpdateUserReq struct {
-	ID    int    `json:"id" binding:"required,min=1"`
-	Name  string `json:"name"`
-	Email string `json:"email" binding:"email"`
+	ID     int        `json:"id" binding:"required,min=1"`
+	Name   string     `json:"name"`
+	Email  string     `json:"email" binding:"email"`
+	Status UserStatus `json:"status" binding:"omitempty,oneof=1 2"`
 }
 
 // UpdateUserResp 结构体
@@ -1,3 +1,5 @@
+// Code scaffolded by kratosgin for example/api/user/v1.
+
 package middleware
 
 import (
@@ -11,8 +13,10 @@ type UserMiddleware struct {
 	log *log.Helper
 }
 
-func NewUserMiddleware() userV1.Middleware {
-	return &UserMiddleware{}
+func NewUserMiddleware(logger log.Logger) userV1.UserServiceMiddleware {
+	return &UserMiddleware{
+		log: log.NewHelper(logger),
+	}
 }
 
 func (m *UserMiddleware) Auth() gin.HandlerFunc {
@@ -0,0 +1,74 @@
+package checker
+
+import (
+	"github.com/YuukiKazuto/kratosgin/internal/parser"
+)
+
+// checkBinding 检查路径参数与请求类型中 uri 标签的字段是否一一对应，以及 gin 不支持的绑定标签
+func (c *checker) checkBinding() {
+	for _, route := range c.template.Routes() {
+		if !route.HasRequest() {
+			for _, param := range parser.PathParams(route.FullPath) {
+				c.diags.Errorf(route.PathPos, CodePathParamMismatch, "路径参数 %s 没有对应的请求类型", param).
+					WithHint("没有请求类型时无法绑定路径参数，请定义包含 uri:\"%s\" 字段的请求类型", param)
+			}
+			continue
+		}
+		if _, ok := c.types[route.Request]; !ok {
+			// 未定义的类型已在 checkMethods 中报告
+			continue
+		}
+		fields := c.template.RequestFields(route.Request)
+		params := make(map[string]bool)
+		for _, param := range parser.PathParams(route.FullPath) {
+			params[param] = true
+			if _, ok := parser.PathParamField(fields, param); ok {
+				continue
+			}
+			c.diags.Errorf(route.PathPos, CodePathParamMismatch, "路径参数 %s 在请求类型 %s 中没有对应的字段", param, route.Request).
+				WithHint("为字段添加 uri:\"%s\" 标签，或者使用相同的 json 名称", param)
+		}
+		c.checkURIFields(route, fields, params)
+	}
+
+	for _, t := range c.template.Types {
+		for _, field := range t.Fields {
+			if name := field.TagName("query"); name != "" {
+				c.diags.Warnf(field.Pos, CodeQueryTag, "字段 %s 的 query 标签不会生效", field.Name).
+					WithHint("gin 使用 form 标签绑定查询参数，请改为 form:\"%s\"", name)
+			}
+		}
+	}
+}
+
+// checkURIFields 检查请求中 uri 标签的名称是否为路由的路径参数
+// 路径中没有该参数时字段不会被绑定，必填的字段会导致每个请求都校验失败，因此报告为错误
+func (c *checker) checkURIFields(route parser.Route, fields []parser.Field, params map[string]bool) {
+	for _, field := range fields {
+		name := field.TagName("uri")
+		if name == "" || name == "-" || params[name] {
+			continue
+		}
+		pos := field.TagPos
+		if !pos.IsValid() {
+			pos = field.Pos
+		}
+		if isRequired(field) {
+			c.diags.Errorf(pos, CodePathParamMismatch, "必填字段 %s 的 uri:\"%s\" 不是路由 %s 的路径参数", field.Name, name, route.FullPath).
+				WithHint("路由在 %s，请在路径中添加 :%s，或者去掉该字段的 uri 标签或 required 规则", route.PathPos, name)
+			continue
+		}
+		c.diags.Warnf(pos, CodeUnusedURI, "字段 %s 的 uri:\"%s\" 不是路由 %s 的路径参数，不会被绑定", field.Name, name, route.FullPath).
+			WithHint("路由在 %s，请在路径中添加 :%s，或者去掉该字段的 uri 标签", route.PathPos, name)
+	}
+}
+
+// isRequired 判断字段的 binding 标签中是否有 required 规则
+func isRequired(field parser.Field) bool {
+	for _, rule := range field.BindingRules() {
+		if rule == "required" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package checker
+
+import "testing"
+
+func TestCheckBinding(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{
+			name: "路径参数与 uri 字段对应",
+			body: `type GetReq {
+	ID int ` + "`uri:\"id\" binding:\"required\"`" + `
+}
+service S {
+	@Get GET /items/:id GetReq -
+}
+`,
+		},
+		{
+			name: "路径参数通过 json 名称绑定",
+			body: `type GetReq {
+	ID int ` + "`json:\"id\"`" + `
+}
+service S {
+	@Get GET /items/:id GetReq -
+}
+`,
+		},
+		{
+			name: "分组前缀中的路径参数",
+			body: `type GetReq {
+	OrgID int ` + "`uri:\"org\" binding:\"required\"`" + `
+	ID    int ` + "`uri:\"id\" binding:\"required\"`" + `
+}
+service S {
+	group /orgs/:org {
+		@Get GET /items/:id GetReq -
+	}
+}
+`,
+		},
+		{
+			name: "路径参数没有对应的字段",
+			body: `type GetReq {
+	Name string ` + "`json:\"name\"`" + `
+}
+service S {
+	@Get GET /items/:id GetReq -
+}
+`,
+			want: []string{"9:11 error[E0112]"},
+		},
+		{
+			name: "路径参数没有请求类型",
+			body: `service S {
+	@Get GET /items/:id - -
+}
+`,
+			want: []string{"6:11 error[E0112]"},
+		},
+		{
+			name: "必填的 uri 字段不是路径参数",
+			body: `type ItemReq {
+	ID   int    ` + "`uri:\"id\" binding:\"required\"`" + `
+	Name string ` + "`json:\"name\"`" + `
+}
+service S {
+	@Create POST /items ItemReq -
+	@Get GET /items/:id ItemReq -
+}
+`,
+			want: []string{"6:14 error[E0112]"},
+		},
+		{
+			name: "可选的 uri 字段不是路径参数",
+			body: `type ListReq {
+	Slug string ` + "`uri:\"slug\"`" + `
+}
+service S {
+	@List GET /items ListReq -
+}
+`,
+			want: []string{"6:14 warning[W0106]"},
+		},
+		{
+			name: "uri 标签为 - 时忽略",
+			body: `type ListReq {
+	Slug string ` + "`uri:\"-\" binding:\"required\"`" + `
+}
+service S {
+	@List GET /items ListReq -
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertDiags(t, checkSource(t, tt.body), tt.want)
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package checker
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/YuukiKazuto/kratosgin/internal/parser"
+)
+
+// 语义检查诊断代码
+const (
+	CodeUndefinedType      = "E0101" // 未定义的类型
+	CodeDuplicateType      = "E0102" // 重复的类型
+	CodeDuplicateMethod    = "E0103" // 重复的方法
+	CodeDuplicateService   = "E0104" // 重复的服务
+	CodeDuplicateGroup     = "E0105" // 重复的路由分组
+	CodeNameCollision      = "E0106" // 生成代码中的标识符冲突
+	CodeDuplicateField     = "E0107" // 重复的字段
+	CodeRouteConflict      = "E0108" // 路由通配符冲突
+	CodeDuplicateRoute     = "E0109" // 重复的路由
+	CodeInvalidRoutePath   = "E0110" // 无效的路由路径
+	CodeNotStruct          = "E0111" // 请求/响应类型不是结构体
+	CodePathParamMismatch  = "E0112" // 路径参数没有对应的请求字段，或必填的 uri 字段不是路径参数
+	CodeInvalidEnvelope    = "E0113" // 无效的响应包装类型
+	CodeDuplicateValidator = "E0114" // 重复的校验规则
+	CodeInvalidValidator   = "E0115" // 无效的校验规则
+	CodeInvalidEnum        = "E0116" // 无效的枚举定义
+	CodeInvalidPackage     = "E0117" // 无效的包名
+
+	CodeUselessSkip    = "W0101" // skip 的中间件没有在上级应用
+	CodeQueryTag       = "W0102" // 使用了 gin 不支持的 query 标签
+	CodeBodyNotAllowed = "W0103" // 状态码不允许响应体
+	CodeEmptyType      = "W0104" // 定义了与 Empty 同名的类型
+	CodeUnknownRule    = "W0105" // binding 标签中使用了未知的校验规则
+	CodeUnusedURI      = "W0106" // uri 标签的名称不是路由的路径参数
+)
+
+// builtinTypes Go 内置类型
+var builtinTypes = map[string]bool{
+	"bool": true, "string": true, "byte": true, "rune": true, "error": true, "any": true,
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true, "uintptr": true,
+	"float32": true, "float64": true, "complex64": true, "complex128": true,
+}
+
+// typeKeywords 类型表达式中的关键字
+var typeKeywords = map[string]bool{
+	"map": true, "interface": true, "struct": true, "chan": true, "func": true,
+}
+
+var identRe = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?`)
+
+// checker 语义检查器
+type checker struct {
+	template *parser.GinTemplate
+	types    map[string]*parser.Type
+	enums    map[string]*parser.Enum
+	diags    parser.Diagnostics
+}
+
+// Check 对解析后的模板做语义检查：包名、枚举、类型引用、重复定义、生成代码的命名冲突、gin 路由冲突、路径参数绑定、响应包装、校验规则和中间件 skip
+func Check(template *parser.GinTemplate) parser.Diagnostics {
+	c := &checker{
+		template: template,
+		types:    make(map[string]*parser.Type),
+		enums:    make(map[string]*parser.Enum),
+	}
+	c.checkOptions()
+	c.checkEnums()
+	c.checkTypes()
+	c.checkNames()
+	c.checkMethods()
+	c.checkRoutes()
+	c.checkBinding()
+	c.checkEnvelope()
+	c.checkValidators()
+	c.checkMiddleware()
+	c.diags.Sort()
+	return c.diags
+}
+
+// checkTypes 检查类型重复定义、字段重复和字段/别名中引用的类型
+func (c *checker) checkTypes() {
+	for i := range c.template.Types {
+		t := &c.template.Types[i]
+		if prev, ok := c.types[t.Name]; ok {
+			c.diags.Errorf(t.Pos, CodeDuplicateType, "类型 %s 重复定义", t.Name).
+				WithHint("之前的定义在 %s", prev.Pos)
+			continue
+		}
+		if prev, ok := c.enums[t.Name]; ok {
+			c.diags.Errorf(t.Pos, CodeDuplicateType, "类型 %s 与枚举重名", t.Name).
+				WithHint("枚举的定义在 %s", prev.Pos)
+			continue
+		}
+		c.types[t.Name] = t
+		if t.Name == parser.EmptyMessage {
+			c.diags.Warnf(t.Pos, CodeEmptyType, "方法中的 %s 表示没有请求体或响应体，不会使用该类型", t.Name).
+				WithHint("请为类型使用其他名称")
+		}
+	}
+
+	for i := range c.template.Types {
+		t := &c.template.Types[i]
+		if t.IsAlias {
+			c.checkTypeExpr(t.AliasTo, t.Pos)
+			continue
+		}
+		fields := make(map[string]parser.Field)
+		for _, field := range t.Fields {
+			name := field.Name
+			if name == "" {
+				// 嵌入字段以类型名作为字段名
+				name = embeddedName(field.Type)
+			}
+			if prev, ok := fields[name]; ok {
+				c.diags.Errorf(field.Pos, CodeDuplicateField, "类型 %s 中字段 %s 重复", t.Name, name).
+					WithHint("之前的定义在 %s", prev.Pos)
+			} else {
+				fields[name] = field
+			}
+			c.checkTypeExpr(field.Type, field.TypePos)
+		}
+	}
+}
+
+// checkTypeExpr 检查类型表达式中引用的类型是否已定义
+func (c *checker) checkTypeExpr(expr string, pos parser.Pos) {
+	for _, ident := range identRe.FindAllString(expr, -1) {
+		// 带包名的类型（如 time.Time）不做检查
+		if strings.Contains(ident, ".") || typeKeywords[ident] || builtinTypes[ident] {
+			continue
+		}
+		if _, ok := c.enums[ident]; ok {
+			continue
+		}
+		if _, ok := c.types[ident]; !ok {
+			c.undefinedType(ident, pos)
+		}
+	}
+}
+
+// checkMethods 检查方法的请求/响应类型和方法名重复
+func (c *checker) checkMethods() {
+	for _, service := range c.template.Services {
+		methods := make(map[string]parser.Method)
+		for _, method := range service.AllMethods() {
+			c.checkMethod(method, service.Name, methods)
+		}
+	}
+
+	for _, group := range c.template.RouteGroups {
+		methods := make(map[string]parser.Method)
+		for _, method := range group.AllMethods() {
+			c.checkMethod(method, "分组 "+group.Name, methods)
+		}
+	}
+
+	methods := make(map[string]parser.Method)
+	for _, route := range c.template.StandaloneRoutes {
+		c.checkMethod(route.Method, "独立路由", methods)
+	}
+}
+
+func (c *checker) checkMethod(method parser.Method, owner string, methods map[string]parser.Method) {
+	// 服务接口中的方法名首字母大写，getUser 与 GetUser 视为同名
+	key := strings.Title(method.Name)
+	if key == "RegisterRoutes" {
+		c.diags.Errorf(method.Pos, CodeNameCollision, "方法名 %s 与生成的路由注册方法冲突", method.Name).
+			WithHint("请使用其他方法名")
+	} else if prev, ok := methods[key]; ok {
+		c.diags.Errorf(method.Pos, CodeDuplicateMethod, "%s 中方法 %s 重复定义", owner, method.Name).
+			WithHint("之前的定义在 %s", prev.Pos)
+	} else {
+		methods[key] = method
+	}
+
+	if method.HasRequest() {
+		c.checkMessageType(method.Request, method.RequestPos, "请求")
+	}
+	if method.HasResponse() {
+		c.checkMessageType(method.Response, method.ResponsePos, "响应")
+		if !bodyAllowed(method.StatusCode()) {
+			c.diags.Warnf(method.ResponsePos, CodeBodyNotAllowed, "状态码 %d 的响应不能包含响应体，%s 不会被写入", method.StatusCode(), method.Response).
+				WithHint("没有响应体时响应类型写 -")
+		}
+	}
+}
+
+// bodyAllowed 判断成功响应能否包含响应体，2xx 中 gin 只会丢弃 204 的响应体
+func bodyAllowed(status int) bool {
+	return status != http.StatusNoContent
+}
+
+// checkMessageType 检查请求/响应类型已定义且最终为结构体
+func (c *checker) checkMessageType(name string, pos parser.Pos, kind string) {
+	t, ok := c.types[name]
+	if !ok {
+		if _, isEnum := c.enums[name]; isEnum {
+			c.diags.Errorf(pos, CodeNotStruct, "%s类型 %s 必须是结构体，实际为枚举", kind, name).
+				WithHint("枚举只能用作字段类型")
+			return
+		}
+		c.undefinedType(name, pos)
+		return
+	}
+
+	// 沿别名链解析到最终类型
+	seen := make(map[string]bool)
+	for t.IsAlias {
+		if seen[t.Name] {
+			return
+		}
+		seen[t.Name] = true
+		next, ok := c.types[t.AliasTo]
+		if !ok {
+			c.diags.Errorf(pos, CodeNotStruct, "%s类型 %s 必须是结构体，实际为 %s", kind, name, t.AliasTo).
+				WithHint("生成的处理器需要通过 &%s{} 创建%s对象", name, kind)
+			return
+		}
+		t = next
+	}
+}
+
+func (c *checker) undefinedType(name string, pos parser.Pos) {
+	d := c.diags.Errorf(pos, CodeUndefinedType, "未定义的类型 %s", name)
+	if similar := c.similarType(name); similar != "" {
+		d.WithHint("是否想使用 %s？", similar)
+	} else {
+		d.WithHint("请先使用 type 定义该类型")
+	}
+}
+
+// similarType 查找大小写不同的同名类型，用于提示
+func (c *checker) similarType(name string) string {
+	for typeName := range c.types {
+		if strings.EqualFold(typeName, name) {
+			return typeName
+		}
+	}
+	return ""
+}
+
+// embeddedName 返回嵌入字段的字段名
+func embeddedName(typ string) string {
+	typ = strings.TrimPrefix(typ, "*")
+	if i := strings.LastIndex(typ, "."); i >= 0 {
+		typ = typ[i+1:]
+	}
+	return typ
+}
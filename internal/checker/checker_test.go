@@ -0,0 +1,52 @@
+package checker
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/YuukiKazuto/kratosgin/internal/parser"
+)
+
+// testHeader 测试用 .gin 源码的公共头部，共 4 行
+const testHeader = `options {
+	packageName: v1
+	outputDir: .
+}
+`
+
+// checkSource 解析并检查 testHeader 加 body 的源码，解析出错时测试失败
+func checkSource(t *testing.T, body string) parser.Diagnostics {
+	t.Helper()
+	file, diags := parser.ParseFile("test.gin", testHeader+body)
+	if diags.HasErrors() {
+		t.Fatalf("解析失败:\n%v", diags)
+	}
+	return Check(parser.BuildTemplate(file))
+}
+
+// diagStrings 以 "行:列 级别[代码]" 的格式列出诊断信息，便于比较
+func diagStrings(diags parser.Diagnostics) []string {
+	list := make([]string, 0, len(diags))
+	for _, d := range diags {
+		list = append(list, fmt.Sprintf("%d:%d %s[%s]", d.Pos.Line, d.Pos.Column, d.Severity, d.Code))
+	}
+	return list
+}
+
+// assertDiags 比较诊断信息，want 为空时要求没有任何诊断
+func assertDiags(t *testing.T, diags parser.Diagnostics, want []string) {
+	t.Helper()
+	got := diagStrings(diags)
+	if len(got) == 0 && len(want) == 0 {
+		return
+	}
+	if !reflect.DeepEqual(got, want) {
+		var messages []string
+		for _, d := range diags {
+			messages = append(messages, d.Error())
+		}
+		t.Errorf("诊断 = %q，期望 %q\n%s", got, want, strings.Join(messages, "\n"))
+	}
+}
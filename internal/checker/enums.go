@@ -0,0 +1,87 @@
+package checker
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/YuukiKazuto/kratosgin/internal/parser"
+)
+
+// enumBitSizes 枚举可以使用的整数基础类型及其位数
+var enumBitSizes = map[string]int{
+	"int": 64, "int8": 8, "int16": 16, "int32": 32, "int64": 64,
+	"uint": 64, "uint8": 8, "uint16": 16, "uint32": 32, "uint64": 64,
+}
+
+// checkEnums 检查枚举重复定义、基础类型以及取值的名称、类型和范围
+func (c *checker) checkEnums() {
+	for i := range c.template.Enums {
+		e := &c.template.Enums[i]
+		if prev, ok := c.enums[e.Name]; ok {
+			c.diags.Errorf(e.Pos, CodeDuplicateType, "枚举 %s 重复定义", e.Name).
+				WithHint("之前的定义在 %s", prev.Pos)
+			continue
+		}
+		c.enums[e.Name] = e
+
+		bitSize, isInt := enumBitSizes[e.Type]
+		if !isInt && !e.IsString() {
+			c.diags.Errorf(e.TypePos, CodeInvalidEnum, "枚举 %s 的基础类型 %s 不受支持", e.Name, e.Type).
+				WithHint("基础类型只能是 string 或 int、int32、uint8 等整数类型")
+			continue
+		}
+		if len(e.Values) == 0 {
+			c.diags.Errorf(e.Pos, CodeInvalidEnum, "枚举 %s 没有定义取值", e.Name).
+				WithHint("例如 enum %s %s { Active = 1 }", e.Name, e.Type)
+			continue
+		}
+
+		names := make(map[string]parser.EnumValue)
+		values := make(map[string]parser.EnumValue)
+		for _, v := range e.Values {
+			if prev, ok := names[v.Name]; ok {
+				c.diags.Errorf(v.Pos, CodeInvalidEnum, "枚举 %s 中取值 %s 重复定义", e.Name, v.Name).
+					WithHint("之前的定义在 %s", prev.Pos)
+			} else {
+				names[v.Name] = v
+			}
+
+			switch {
+			case e.IsString() && !v.IsString:
+				c.diags.Errorf(v.ValuePos, CodeInvalidEnum, "字符串枚举 %s 的取值 %s 必须是字符串", e.Name, v.Name).
+					WithHint("例如 %s = \"%s\"", v.Name, strings.ToLower(v.Name))
+				continue
+			case !e.IsString() && v.IsString:
+				c.diags.Errorf(v.ValuePos, CodeInvalidEnum, "%s 枚举 %s 的取值 %s 必须是整数", e.Type, e.Name, v.Name).
+					WithHint("字符串取值需要使用 enum %s string { ... }", e.Name)
+				continue
+			case v.IsString && strings.ContainsAny(v.Value, "'`"):
+				c.diags.Errorf(v.ValuePos, CodeInvalidEnum, "枚举 %s 的取值 %s 不能包含单引号或反引号", e.Name, v.Name).
+					WithHint("取值会写入 binding 标签的 oneof 规则")
+				continue
+			case !v.IsString && !intInRange(v.Value, e.Type, bitSize):
+				c.diags.Errorf(v.ValuePos, CodeInvalidEnum, "枚举 %s 的取值 %s 超出了 %s 的范围", e.Name, v.Value, e.Type).
+					WithHint("请使用更大的基础类型")
+				continue
+			}
+
+			if prev, ok := values[v.Value]; ok {
+				c.diags.Errorf(v.ValuePos, CodeInvalidEnum, "枚举 %s 中 %s 与 %s 的取值相同", e.Name, v.Name, prev.Name).
+					WithHint("之前的定义在 %s", prev.Pos)
+			} else {
+				values[v.Value] = v
+			}
+		}
+	}
+}
+
+// intInRange 判断整数字面量是否在基础类型的范围内
+func intInRange(value, typ string, bitSize int) bool {
+	var err error
+	if strings.HasPrefix(typ, "uint") {
+		_, err = strconv.ParseUint(value, 10, bitSize)
+	} else {
+		_, err = strconv.ParseInt(value, 10, bitSize)
+	}
+	return err == nil
+}
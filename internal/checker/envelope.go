@@ -0,0 +1,38 @@
+package checker
+
+import "go/token"
+
+// checkEnvelope 检查自定义的响应包装类型：必须是 .gin 中定义的结构体，并且只有一个 any 或 interface{} 类型的数据字段
+func (c *checker) checkEnvelope() {
+	options := c.template.Options
+	if !options.HasEnvelope() || options.StandardEnvelope() {
+		return
+	}
+	pos := options.EnvelopePos
+
+	t, ok := c.types[options.Envelope]
+	if !ok {
+		c.undefinedType(options.Envelope, pos)
+		return
+	}
+	if t.IsAlias {
+		c.diags.Errorf(pos, CodeNotStruct, "响应包装类型 %s 必须是结构体，实际为 %s", t.Name, t.AliasTo).
+			WithHint("请直接定义包装结构体，或者使用 envelope: standard")
+		return
+	}
+
+	switch fields := t.EnvelopeDataFields(); len(fields) {
+	case 0:
+		c.diags.Errorf(pos, CodeInvalidEnvelope, "响应包装类型 %s 中没有存放响应数据的字段", t.Name).
+			WithHint("添加一个 interface{} 类型的字段，例如 Data interface{} `json:\"data\"`")
+	case 1:
+	default:
+		c.diags.Errorf(fields[1].Pos, CodeInvalidEnvelope, "响应包装类型 %s 中有多个 interface{} 类型的字段", t.Name).
+			WithHint("只能有一个存放响应数据的字段，之前的字段为 %s", fields[0].Name)
+	}
+
+	if name := options.EnvelopeFuncName(); !token.IsIdentifier(name) {
+		c.diags.Errorf(pos, CodeInvalidEnvelope, "无效的响应包装构造函数名 %q", name).
+			WithHint("envelopeFunc 必须是同一个包中的函数名")
+	}
+}
@@ -0,0 +1,68 @@
+package checker
+
+import (
+	"strings"
+
+	"github.com/YuukiKazuto/kratosgin/internal/parser"
+)
+
+// checkMiddleware 检查分组和方法中 skip 的中间件是否由上级应用
+func (c *checker) checkMiddleware() {
+	for _, service := range c.template.Services {
+		inherited := service.Middleware
+		for _, method := range service.Methods {
+			c.checkSkip(method.Skip, inherited, method.Pos, "方法 "+method.Name)
+		}
+		for _, group := range service.RouteGroups {
+			c.checkGroupMiddleware(group, inherited)
+		}
+	}
+	for _, group := range c.template.RouteGroups {
+		c.checkGroupMiddleware(group, nil)
+	}
+	for _, route := range c.template.StandaloneRoutes {
+		c.checkSkip(route.Skip, nil, route.Pos, "路由 "+route.Name)
+	}
+}
+
+func (c *checker) checkGroupMiddleware(group parser.RouteGroup, inherited []string) {
+	c.checkSkip(group.Skip, inherited, group.Pos, "分组 "+group.Name)
+
+	// 与生成器一致：去掉 skip 的中间件，再追加分组自身的中间件
+	skipped := make(map[string]bool)
+	for _, name := range group.Skip {
+		skipped[name] = true
+	}
+	chain := make([]string, 0, len(inherited)+len(group.Middleware))
+	for _, name := range inherited {
+		if !skipped[name] {
+			chain = append(chain, name)
+		}
+	}
+	chain = append(chain, group.Middleware...)
+
+	for _, method := range group.Methods {
+		c.checkSkip(method.Skip, chain, method.Pos, "方法 "+method.Name)
+	}
+	for _, child := range group.Groups {
+		c.checkGroupMiddleware(child, chain)
+	}
+}
+
+func (c *checker) checkSkip(skip, inherited []string, pos parser.Pos, owner string) {
+	applied := make(map[string]bool)
+	for _, name := range inherited {
+		applied[name] = true
+	}
+	for _, name := range skip {
+		if applied[name] {
+			continue
+		}
+		d := c.diags.Warnf(pos, CodeUselessSkip, "%s 跳过的中间件 %s 没有在上级应用", owner, name)
+		if len(inherited) > 0 {
+			d.WithHint("上级应用的中间件: %s", strings.Join(inherited, "、"))
+		} else {
+			d.WithHint("上级没有应用任何中间件，可以删除该 skip")
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package checker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/YuukiKazuto/kratosgin/internal/generator"
+	"github.com/YuukiKazuto/kratosgin/internal/parser"
+)
+
+// declaration 表示生成代码中的一个包级标识符
+type declaration struct {
+	pos  parser.Pos // 生成器内置的标识符没有位置
+	what string
+}
+
+// checkNames 检查服务、分组和类型在生成的 Go 包中是否产生同名标识符
+func (c *checker) checkNames() {
+	names := make(map[string]declaration)
+
+	// 生成器固定输出的标识符
+	for _, name := range generator.FixedNames(c.template) {
+		names[name] = declaration{what: "生成代码中的 " + name}
+	}
+
+	declare := func(name string, pos parser.Pos, what, code string) {
+		prev, ok := names[name]
+		if !ok {
+			names[name] = declaration{pos: pos, what: what}
+			return
+		}
+		d := c.diags.Errorf(pos, code, "%s 与%s重名", what, prev.what)
+		if prev.pos.IsValid() {
+			d.WithHint("之前的定义在 %s", prev.pos)
+		} else {
+			d.WithHint("%s 由生成器使用，请换一个名称", name)
+		}
+	}
+
+	for _, t := range c.template.Types {
+		// 重复的类型已在 checkTypes 中报告
+		if prev := c.types[t.Name]; prev == nil || prev.Pos != t.Pos {
+			continue
+		}
+		declare(t.Name, t.Pos, fmt.Sprintf("类型 %s", t.Name), CodeNameCollision)
+	}
+
+	for _, e := range c.template.Enums {
+		// 重复的枚举已在 checkEnums 中报告
+		if c.enums[e.Name].Pos != e.Pos {
+			continue
+		}
+		declare(e.Name, e.Pos, fmt.Sprintf("枚举 %s", e.Name), CodeNameCollision)
+		seen := make(map[string]bool)
+		for _, v := range e.Values {
+			// 重复的取值已在 checkEnums 中报告
+			if seen[v.Name] {
+				continue
+			}
+			seen[v.Name] = true
+			declare(v.Const, v.Pos, fmt.Sprintf("枚举 %s 的常量 %s", e.Name, v.Const), CodeNameCollision)
+		}
+	}
+
+	// validate: true 时请求类型上生成 Validate 方法，字段不能与其同名
+	if c.template.Options.Validate {
+		for _, t := range c.template.RequestTypes() {
+			for _, field := range t.Fields {
+				if field.Name == "Validate" || (field.Name == "" && embeddedName(field.Type) == "Validate") {
+					c.diags.Errorf(field.Pos, CodeNameCollision, "请求类型 %s 的字段 Validate 与生成的 Validate 方法冲突", t.Name).
+						WithHint("请重命名该字段，或者关闭 validate 选项")
+				}
+			}
+		}
+	}
+
+	for _, service := range c.template.Services {
+		if prev, ok := names[service.Name]; ok && prev.what == "服务 "+service.Name {
+			c.diags.Errorf(service.Pos, CodeDuplicateService, "服务 %s 重复定义", service.Name).
+				WithHint("之前的定义在 %s", prev.pos)
+			continue
+		}
+		declare(service.Name, service.Pos, "服务 "+service.Name, CodeNameCollision)
+		declare(service.Name+"Handler", service.Pos, fmt.Sprintf("服务 %s 的处理器 %sHandler", service.Name, service.Name), CodeNameCollision)
+		if c.template.Options.Client {
+			declare(service.Name+"HTTPClient", service.Pos, fmt.Sprintf("服务 %s 的 HTTP 客户端 %sHTTPClient", service.Name, service.Name), CodeNameCollision)
+			declare("New"+service.Name+"HTTPClient", service.Pos, fmt.Sprintf("服务 %s 的 HTTP 客户端构造函数 New%sHTTPClient", service.Name, service.Name), CodeNameCollision)
+		}
+		if serviceHasMiddleware(service) {
+			declare(service.Name+"Middleware", service.Pos, fmt.Sprintf("服务 %s 的中间件接口 %sMiddleware", service.Name, service.Name), CodeNameCollision)
+		}
+
+		for _, group := range service.RouteGroups {
+			if service.Prefix != "" && groupVarName("", group.Name) == "PrefixGroup" {
+				c.diags.Errorf(group.Pos, CodeNameCollision, "分组 %s 与服务前缀生成的 PrefixGroup 变量重名", group.Name).
+					WithHint("请换一个分组名")
+			}
+		}
+		c.checkGroupNames(service.RouteGroups, "", "服务 "+service.Name)
+	}
+
+	for _, group := range c.template.RouteGroups {
+		// 顶级分组生成 <Group>Service 服务接口和 <Group>Handler 处理器
+		name := strings.Title(group.Name) + "Handler"
+		if prev, ok := names[name]; ok && prev.what == "分组 "+group.Name+" 的处理器" {
+			c.diags.Errorf(group.Pos, CodeDuplicateGroup, "分组 %s 重复定义", group.Name).
+				WithHint("之前的定义在 %s", prev.pos)
+			continue
+		}
+		declare(name, group.Pos, "分组 "+group.Name+" 的处理器", CodeNameCollision)
+		service := parser.GroupServiceName(group.Name)
+		declare(service, group.Pos, fmt.Sprintf("分组 %s 的服务接口 %s", group.Name, service), CodeNameCollision)
+		if c.template.Options.Client {
+			declare(service+"HTTPClient", group.Pos, fmt.Sprintf("分组 %s 的 HTTP 客户端 %sHTTPClient", group.Name, service), CodeNameCollision)
+			declare("New"+service+"HTTPClient", group.Pos, fmt.Sprintf("分组 %s 的 HTTP 客户端构造函数 New%sHTTPClient", group.Name, service), CodeNameCollision)
+		}
+		if groupHasMiddleware(group) {
+			iface := strings.Title(group.Name) + "Middleware"
+			declare(iface, group.Pos, fmt.Sprintf("分组 %s 的中间件接口 %s", group.Name, iface), CodeNameCollision)
+		}
+		c.checkGroupNames(group.Groups, groupVarName("", group.Name), "分组 "+group.Name)
+	}
+}
+
+// checkGroupNames 递归检查同一层级的分组是否生成同名的局部变量
+func (c *checker) checkGroupNames(groups []parser.RouteGroup, parentVar, owner string) {
+	seen := make(map[string]parser.RouteGroup)
+	for _, group := range groups {
+		// 分组在 RegisterRoutes 中生成 <Parent><Name>Group 局部变量
+		key := groupVarName(parentVar, group.Name)
+		if prev, ok := seen[key]; ok {
+			c.diags.Errorf(group.Pos, CodeDuplicateGroup, "%s 中分组 %s 重复定义", owner, group.Name).
+				WithHint("之前的定义在 %s", prev.Pos)
+			continue
+		}
+		seen[key] = group
+		c.checkGroupNames(group.Groups, key, "分组 "+group.Name)
+	}
+}
+
+// groupVarName 返回分组在 RegisterRoutes 中的变量名，与生成器保持一致
+func groupVarName(parentVar, name string) string {
+	return strings.TrimSuffix(parentVar, "Group") + strings.Title(name) + "Group"
+}
+
+// serviceHasMiddleware 判断服务是否生成中间件接口
+func serviceHasMiddleware(service parser.Service) bool {
+	if len(service.Middleware) > 0 {
+		return true
+	}
+	for _, method := range service.Methods {
+		if len(method.Middleware) > 0 {
+			return true
+		}
+	}
+	for _, group := range service.RouteGroups {
+		if groupHasMiddleware(group) {
+			return true
+		}
+	}
+	return false
+}
+
+// groupHasMiddleware 判断分组、子分组或其中的方法是否声明了中间件
+func groupHasMiddleware(group parser.RouteGroup) bool {
+	if len(group.Middleware) > 0 {
+		return true
+	}
+	for _, method := range group.Methods {
+		if len(method.Middleware) > 0 {
+			return true
+		}
+	}
+	for _, child := range group.Groups {
+		if groupHasMiddleware(child) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,15 @@
+package checker
+
+import "go/token"
+
+// checkOptions 检查配置项：packageName 必须是合法的 Go 包名
+func (c *checker) checkOptions() {
+	options := c.template.Options
+	if options.PackageName == "" {
+		return
+	}
+	if !token.IsIdentifier(options.PackageName) || options.PackageName == "_" {
+		c.diags.Errorf(options.PackageNamePos, CodeInvalidPackage, "无效的包名 %q", options.PackageName).
+			WithHint("packageName 必须是合法的 Go 标识符，例如 v1")
+	}
+}
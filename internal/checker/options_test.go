@@ -0,0 +1,32 @@
+package checker
+
+import (
+	"testing"
+
+	"github.com/YuukiKazuto/kratosgin/internal/parser"
+)
+
+func TestCheckOptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		packageName string
+		want        []string
+	}{
+		{name: "合法的包名", packageName: "v1"},
+		{name: "下划线开头", packageName: "_v1"},
+		{name: "包含短横线", packageName: "user-v1", want: []string{"2:15 error[E0117]"}},
+		{name: "数字开头", packageName: "1v", want: []string{"2:15 error[E0117]"}},
+		{name: "关键字", packageName: "type", want: []string{"2:15 error[E0117]"}},
+		{name: "空白标识符", packageName: "_", want: []string{"2:15 error[E0117]"}},
+		{name: "带引号", packageName: `"v 1"`, want: []string{"2:15 error[E0117]"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, diags := parser.ParseFile("test.gin", "options {\n\tpackageName: "+tt.packageName+"\n}\n")
+			if diags.HasErrors() {
+				t.Fatalf("解析失败:\n%v", diags)
+			}
+			assertDiags(t, Check(parser.BuildTemplate(file)), tt.want)
+		})
+	}
+}
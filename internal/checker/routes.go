@@ -0,0 +1,186 @@
+package checker
+
+import (
+	"strings"
+
+	"github.com/YuukiKazuto/kratosgin/internal/parser"
+)
+
+// route 表示一条最终注册到 gin 的路由
+type route struct {
+	method string
+	path   string
+	name   string
+	pos    parser.Pos
+}
+
+// routeNode 按路径段模拟 gin 的路由树
+// gin 允许同一位置同时存在静态段和参数段，但不允许参数名不同，也不允许 catch-all 与其他段共存
+type routeNode struct {
+	static        map[string]*routeNode
+	param         *routeNode
+	paramName     string
+	paramRoute    *route
+	catchAll      *routeNode
+	catchAllName  string
+	catchAllRoute *route
+	childRoute    *route // 第一个在此位置创建子节点的路由
+	route         *route
+}
+
+// checkRoutes 按 RegisterRoutes 的注册方式拼出完整路径，检查是否会导致 gin 在启动时 panic
+func (c *checker) checkRoutes() {
+	trees := make(map[string]*routeNode)
+	add := func(method parser.Method, base string) {
+		r := &route{
+			method: method.HTTPMethod,
+			path:   parser.JoinPath(base, method.Path),
+			name:   method.Name,
+			pos:    method.PathPos,
+		}
+		tree, ok := trees[r.method]
+		if !ok {
+			tree = &routeNode{}
+			trees[r.method] = tree
+		}
+		c.insertRoute(tree, r)
+	}
+	var addGroup func(group parser.RouteGroup, base string)
+	addGroup = func(group parser.RouteGroup, base string) {
+		groupBase := parser.JoinPath(base, group.Path)
+		for _, method := range group.Methods {
+			add(method, groupBase)
+		}
+		for _, child := range group.Groups {
+			addGroup(child, groupBase)
+		}
+	}
+
+	for _, service := range c.template.Services {
+		base := "/"
+		if service.Prefix != "" {
+			base = parser.JoinPath(base, "/"+service.Prefix)
+		}
+		for _, method := range service.Methods {
+			add(method, base)
+		}
+		for _, group := range service.RouteGroups {
+			addGroup(group, base)
+		}
+	}
+	for _, group := range c.template.RouteGroups {
+		addGroup(group, "/")
+	}
+	for _, standalone := range c.template.StandaloneRoutes {
+		add(standalone.Method, "/")
+	}
+}
+
+// insertRoute 将路由插入路由树，报告与已有路由的冲突
+func (c *checker) insertRoute(node *routeNode, r *route) {
+	segments := strings.Split(strings.TrimPrefix(r.path, "/"), "/")
+	for i, segment := range segments {
+		kind, name, ok := c.parseSegment(r, segment, i == len(segments)-1)
+		if !ok {
+			return
+		}
+
+		parent := node
+		switch kind {
+		case ':':
+			if parent.catchAll != nil {
+				c.routeConflict(r, parent.catchAllRoute, "通配符 *"+parent.catchAllName)
+				return
+			}
+			if parent.param != nil && parent.paramName != name {
+				c.routeConflict(r, parent.paramRoute, "参数 :"+parent.paramName)
+				return
+			}
+			if parent.param == nil {
+				parent.param = &routeNode{}
+				parent.paramName = name
+				parent.paramRoute = r
+			}
+			node = parent.param
+		case '*':
+			if parent.catchAll == nil && parent.childRoute != nil {
+				c.routeConflict(r, parent.childRoute, "")
+				return
+			}
+			if parent.catchAll != nil && parent.catchAllName != name {
+				c.routeConflict(r, parent.catchAllRoute, "通配符 *"+parent.catchAllName)
+				return
+			}
+			if parent.catchAll == nil {
+				parent.catchAll = &routeNode{}
+				parent.catchAllName = name
+				parent.catchAllRoute = r
+			}
+			node = parent.catchAll
+		default:
+			if parent.catchAll != nil {
+				c.routeConflict(r, parent.catchAllRoute, "通配符 *"+parent.catchAllName)
+				return
+			}
+			if parent.static == nil {
+				parent.static = make(map[string]*routeNode)
+			}
+			child, ok := parent.static[segment]
+			if !ok {
+				child = &routeNode{}
+				parent.static[segment] = child
+			}
+			node = child
+		}
+		if parent.childRoute == nil {
+			parent.childRoute = r
+		}
+	}
+
+	if node.route != nil {
+		c.diags.Errorf(r.pos, CodeDuplicateRoute, "路由 %s %s 重复注册", r.method, r.path).
+			WithHint("%s 已在 %s 注册该路由，gin 启动时会 panic", node.route.name, node.route.pos)
+		return
+	}
+	node.route = r
+}
+
+// parseSegment 解析路径段，返回通配符类型（':'、'*' 或 0）和名称，并检查 gin 对通配符的限制
+func (c *checker) parseSegment(r *route, segment string, last bool) (byte, string, bool) {
+	index := strings.IndexAny(segment, ":*")
+	if index < 0 {
+		return 0, "", true
+	}
+	kind := segment[index]
+	name := segment[index+1:]
+
+	invalid := func(format string, args ...interface{}) (byte, string, bool) {
+		c.diags.Errorf(r.pos, CodeInvalidRoutePath, format, args...).
+			WithHint("完整路径为 %s，gin 注册该路由时会 panic", r.path)
+		return 0, "", false
+	}
+	switch {
+	case strings.ContainsAny(name, ":*"):
+		return invalid("路径段 %s 中只能有一个通配符", segment)
+	case name == "":
+		return invalid("路径段 %s 中的通配符缺少名称", segment)
+	case kind == '*' && index > 0:
+		return invalid("catch-all 通配符 %s 必须紧跟在 '/' 之后", segment)
+	case kind == '*' && !last:
+		return invalid("catch-all 通配符 %s 只能出现在路径末尾", segment)
+	case index > 0:
+		// 段中间的参数（如 /file:name）按静态段处理
+		return 0, "", true
+	}
+	return kind, name, true
+}
+
+// routeConflict 报告路由冲突
+func (c *checker) routeConflict(r, existing *route, detail string) {
+	d := c.diags.Errorf(r.pos, CodeRouteConflict, "路由 %s %s 与已注册的 %s %s 冲突", r.method, r.path, existing.method, existing.path)
+	if detail != "" {
+		d.WithHint("同一位置已存在%s（%s 定义于 %s），gin 启动时会 panic", detail, existing.name, existing.pos)
+	} else {
+		d.WithHint("catch-all 通配符不能与同一位置的其他路径共存（%s 定义于 %s），gin 启动时会 panic", existing.name, existing.pos)
+	}
+}
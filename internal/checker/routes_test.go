@@ -0,0 +1,125 @@
+package checker
+
+import (
+	"testing"
+
+	"github.com/YuukiKazuto/kratosgin/internal/parser"
+)
+
+// routeTypes 路由测试共用的请求和响应类型
+const routeTypes = `
+type Req {
+	ID   string ` + "`json:\"id\"`" + `
+	UID  string ` + "`json:\"uid\"`" + `
+	Path string ` + "`json:\"path\"`" + `
+}
+type Resp {
+	OK bool ` + "`json:\"ok\"`" + `
+}
+`
+
+// routeDiags 只保留路由相关的诊断
+func routeDiags(diags parser.Diagnostics) parser.Diagnostics {
+	var result parser.Diagnostics
+	for _, d := range diags {
+		switch d.Code {
+		case CodeRouteConflict, CodeDuplicateRoute, CodeInvalidRoutePath:
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+func TestCheckRoutes(t *testing.T) {
+	tests := []struct {
+		name string
+		body string // 第 5 行开始
+		want []string
+	}{
+		{
+			name: "静态段与参数段共存",
+			body: `service S {
+	@A GET /users/:id Req Resp
+	@B GET /users/new Req Resp
+	@C GET /users/:id/posts Req Resp
+}`,
+		},
+		{
+			name: "不同方法的相同路径",
+			body: `service S {
+	@A GET /users/:id Req Resp
+	@B DELETE /users/:id Req Resp
+}`,
+		},
+		{
+			name: "同一位置的参数名不同",
+			body: `service S {
+	@A GET /users/:id Req Resp
+	@B GET /users/:uid/posts Req Resp
+}`,
+			want: []string{"7:9 error[E0108]"},
+		},
+		{
+			name: "重复的路由，末尾的 / 视为不同的路由",
+			body: `service S {
+	@A GET /users Req Resp
+	@B GET /users Req Resp
+	@C GET /users/ Req Resp
+}`,
+			want: []string{"7:9 error[E0109]"},
+		},
+		{
+			name: "catch-all 之后注册静态段",
+			body: `service S {
+	@A GET /files/*path Req Resp
+	@B GET /files/x Req Resp
+}`,
+			want: []string{"7:9 error[E0108]"},
+		},
+		{
+			name: "静态段之后注册 catch-all",
+			body: `service S {
+	@A GET /files/x Req Resp
+	@B GET /files/*path Req Resp
+}`,
+			want: []string{"7:9 error[E0108]"},
+		},
+		{
+			name: "无效的通配符",
+			body: `service S {
+	@A GET /files/*path/x Req Resp
+	@B GET /users/: Req Resp
+	@C GET /a/b*path Req Resp
+	@D GET /a/:id:uid Req Resp
+}`,
+			want: []string{"6:9 error[E0110]", "7:9 error[E0110]", "8:9 error[E0110]", "9:9 error[E0110]"},
+		},
+		{
+			name: "服务前缀和分组拼接后冲突",
+			body: `service S prefix v1 {
+	group @users /users {
+		@A GET /:id Req Resp
+	}
+}
+service T {
+	@B GET /v1/users/:uid Req Resp
+	@C GET /v1/users/:id Req Resp
+}`,
+			want: []string{"11:9 error[E0108]", "12:9 error[E0109]"},
+		},
+		{
+			name: "顶级分组和独立路由",
+			body: `group @admin /v1 {
+	@A GET /users/:id Req Resp
+}
+@B GET /v1/users/:uid Req Resp
+@C GET /v1/users/:id Req Resp`,
+			want: []string{"8:8 error[E0108]", "9:8 error[E0109]"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertDiags(t, routeDiags(checkSource(t, tt.body+"\n"+routeTypes)), tt.want)
+		})
+	}
+}
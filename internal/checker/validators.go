@@ -0,0 +1,100 @@
+package checker
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/YuukiKazuto/kratosgin/internal/parser"
+)
+
+// ruleValidator 用于判断规则名是否为 validator 的内置规则，内置规则以 kratosgin 依赖的 validator 版本为准
+var ruleValidator = validator.New()
+
+// builtinRules 缓存 isBuiltinRule 的结果
+var builtinRules sync.Map
+
+// isBuiltinRule 判断规则名是否为 validator 的内置规则或 binding 标签中可用的控制标签（如 omitempty、dive）
+// validator 解析标签时遇到未注册的规则会 panic，据此判断，不需要维护内置规则的列表
+func isBuiltinRule(name string) bool {
+	if known, ok := builtinRules.Load(name); ok {
+		return known.(bool)
+	}
+	known := true
+	func() {
+		defer func() {
+			// 其他 panic 来自缺少参数或位置不对的控制标签，规则本身是存在的
+			if r := recover(); r != nil {
+				known = !strings.HasPrefix(fmt.Sprint(r), "Undefined validation function")
+			}
+		}()
+		// 值为 nil 时 validator 只解析标签，不执行校验函数
+		_ = ruleValidator.Var(nil, name)
+	}()
+	builtinRules.Store(name, known)
+	return known
+}
+
+// checkValidators 检查 validators 块中的规则定义，包括与内置规则同名的规则，以及 binding 标签中既不是内置规则也没有声明的规则名
+func (c *checker) checkValidators() {
+	declared := make(map[string]parser.Validator)
+	for _, v := range c.template.Validators {
+		if prev, ok := declared[v.Name]; ok {
+			c.diags.Errorf(v.Pos, CodeDuplicateValidator, "校验规则 %s 重复定义", v.Name).
+				WithHint("之前的定义在 %s", prev.Pos)
+			continue
+		}
+		declared[v.Name] = v
+
+		if isBuiltinRule(v.Name) {
+			c.diags.Errorf(v.Pos, CodeInvalidValidator, "校验规则 %s 与 validator 的内置规则同名", v.Name).
+				WithHint("注册同名规则会替换内置规则，请使用其他名称")
+		}
+		switch v.Kind {
+		case parser.ValidatorKindRegex:
+			if !v.PatternPos.IsValid() {
+				c.diags.Errorf(v.KindPos, CodeInvalidValidator, "校验规则 %s 缺少正则表达式", v.Name).
+					WithHint("例如 %s regex \"^[a-z]+$\"", v.Name)
+			} else if _, err := regexp.Compile(v.Pattern); err != nil {
+				c.diags.Errorf(v.PatternPos, CodeInvalidValidator, "校验规则 %s 的正则表达式无效: %v", v.Name, err).
+					WithHint("双引号字符串中的 \\ 需要写成 \\\\，也可以使用反引号字符串")
+			}
+		case parser.ValidatorKindExternal:
+			if v.PatternPos.IsValid() {
+				c.diags.Errorf(v.PatternPos, CodeInvalidValidator, "手动注册的校验规则 %s 不能指定正则表达式", v.Name).
+					WithHint("使用正则表达式校验时请将类型改为 regex")
+			}
+		default:
+			c.diags.Errorf(v.KindPos, CodeInvalidValidator, "校验规则 %s 的类型 %s 不受支持", v.Name, v.Kind).
+				WithHint("支持 regex 和 external，例如 %s regex \"^[a-z]+$\"", v.Name)
+		}
+		locales := make(map[string]parser.ValidatorMessage)
+		for _, message := range v.Messages {
+			if prev, ok := locales[message.Locale]; ok {
+				c.diags.Errorf(message.Pos, CodeInvalidValidator, "校验规则 %s 中 %s 的错误信息重复", v.Name, message.Locale).
+					WithHint("之前的定义在 %s", prev.Pos)
+				continue
+			}
+			locales[message.Locale] = message
+		}
+	}
+
+	for _, t := range c.template.Types {
+		for _, field := range t.Fields {
+			for _, rule := range field.BindingRules() {
+				if _, ok := declared[rule]; ok || isBuiltinRule(rule) {
+					continue
+				}
+				pos := field.TagPos
+				if !pos.IsValid() {
+					pos = field.Pos
+				}
+				c.diags.Warnf(pos, CodeUnknownRule, "字段 %s 使用了未知的校验规则 %s", field.Name, rule).
+					WithHint("在 validators 块中声明该规则，例如 %s regex \"...\"；在代码中手动注册的规则声明为 %s external；未注册的规则在校验时会导致 panic", rule, rule)
+			}
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package checker
+
+import "testing"
+
+func TestCheckValidators(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{
+			name: "声明的规则和内置规则",
+			body: `validators {
+	phone regex "^1[3-9]\\d{9}$" zh: "{0}必须是有效的手机号"
+	custom external en: "{0} is invalid"
+}
+type Req {
+	Phone string ` + "`json:\"phone\" binding:\"required,phone|email\"`" + `
+	Name  string ` + "`json:\"name\" binding:\"omitempty,custom,min=2\"`" + `
+}
+`,
+		},
+		{
+			name: "未知的规则",
+			body: `type Req {
+	Phone string ` + "`json:\"phone\" binding:\"required,phone\"`" + `
+}
+`,
+			want: []string{"6:15 warning[W0105]"},
+		},
+		{
+			name: "与内置规则同名",
+			body: `validators {
+	email regex "^.+@example\\.com$"
+	required external
+}
+`,
+			want: []string{"6:2 error[E0115]", "7:2 error[E0115]"},
+		},
+		{
+			name: "规则类型和正则表达式",
+			body: `validators {
+	a regex
+	b regex "("
+	c external "^x$"
+	d func "^x$"
+}
+`,
+			want: []string{"6:4 error[E0115]", "7:10 error[E0115]", "8:13 error[E0115]", "9:4 error[E0115]"},
+		},
+		{
+			name: "重复的规则和错误信息",
+			body: `validators {
+	phone regex "^1\\d{10}$" zh: "a" zh: "b"
+	phone regex "^1\\d{10}$"
+}
+`,
+			want: []string{"6:35 error[E0115]", "7:2 error[E0114]"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertDiags(t, checkSource(t, tt.body), tt.want)
+		})
+	}
+}
+
+func TestIsBuiltinRule(t *testing.T) {
+	tests := []struct {
+		rule string
+		want bool
+	}{
+		{"required", true},
+		{"email", true},
+		{"min", true},         // 缺少参数
+		{"required_if", true}, // 缺少参数
+		{"omitempty", true},   // 控制标签
+		{"dive", true},        // 控制标签
+		{"keys", true},        // 没有紧跟在 dive 之后
+		{"iscolor", true},     // 别名
+		{"-", true},           // 跳过校验
+		{"phone", false},      // 需要在 validators 块中声明
+		{"Required", false},   // 规则名区分大小写
+		{"not_a_rule_123", false},
+	}
+	for _, tt := range tests {
+		if got := isBuiltinRule(tt.rule); got != tt.want {
+			t.Errorf("isBuiltinRule(%q) = %v，期望 %v", tt.rule, got, tt.want)
+		}
+	}
+}
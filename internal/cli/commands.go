@@ -7,8 +7,11 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/YuukiKazuto/kratosgin/internal/checker"
+	"github.com/YuukiKazuto/kratosgin/internal/client"
 	"github.com/YuukiKazuto/kratosgin/internal/formatter"
 	"github.com/YuukiKazuto/kratosgin/internal/generator"
+	"github.com/YuukiKazuto/kratosgin/internal/openapi"
 	"github.com/YuukiKazuto/kratosgin/internal/parser"
 	"github.com/YuukiKazuto/kratosgin/internal/templates"
 	"github.com/spf13/cobra"
@@ -20,6 +23,7 @@ func GenCommand() *cobra.Command {
 		templateFile        string
 		serviceOutputDir    string
 		middlewareOutputDir string
+		prune               bool
 	)
 
 	cmd := &cobra.Command{
@@ -27,13 +31,14 @@ func GenCommand() *cobra.Command {
 		Short: "生成 API 代码",
 		Long:  "根据 .gin 模板文件生成 Kratos API 代码",
 		Run: func(cmd *cobra.Command, args []string) {
-			runGen(templateFile, serviceOutputDir, middlewareOutputDir)
+			runGen(templateFile, serviceOutputDir, middlewareOutputDir, prune)
 		},
 	}
 
 	cmd.Flags().StringVarP(&templateFile, "file", "f", "", "模板文件路径 (.gin 文件)")
 	cmd.Flags().StringVarP(&serviceOutputDir, "service", "s", "", "Service 实现输出目录")
 	cmd.Flags().StringVarP(&middlewareOutputDir, "middleware", "m", "", "Middleware 实现输出目录")
+	cmd.Flags().BoolVar(&prune, "prune", false, "删除不再使用的 Middleware 实现文件（删除前需确认）")
 	cmd.MarkFlagRequired("file")
 
 	return cmd
@@ -59,7 +64,7 @@ func NewCommand() *cobra.Command {
 }
 
 // runGen 执行生成命令
-func runGen(templateFile, serviceOutputDir, middlewareOutputDir string) {
+func runGen(templateFile, serviceOutputDir, middlewareOutputDir string, prune bool) {
 	// 检查文件是否存在
 	if _, err := os.Stat(templateFile); os.IsNotExist(err) {
 		log.Fatalf("模板文件不存在: %s", templateFile)
@@ -68,26 +73,18 @@ func runGen(templateFile, serviceOutputDir, middlewareOutputDir string) {
 	// 在生成之前自动格式化 gin 文件
 	if strings.HasSuffix(templateFile, ".gin") {
 		fmt.Printf("正在格式化 gin 文件: %s\n", templateFile)
+		// 格式化失败时不修改文件，语法错误由随后的解析报告
 		if err := formatter.FormatGinFileWithBackup(templateFile); err != nil {
-			log.Fatalf("格式化 gin 文件失败: %v", err)
+			log.Printf("跳过格式化: %v", err)
+		} else {
+			fmt.Printf("gin 文件格式化完成\n")
 		}
-		fmt.Printf("gin 文件格式化完成\n")
 	}
 
 	// 获取 gin 文件所在的目录
 	ginDir := filepath.Dir(templateFile)
 
-	// 读取文件内容
-	content, err := os.ReadFile(templateFile)
-	if err != nil {
-		log.Fatalf("读取模板文件失败: %v", err)
-	}
-
-	// 解析模板
-	template, err := parser.ParseGinTemplate(string(content))
-	if err != nil {
-		log.Fatalf("解析模板失败: %v", err)
-	}
+	template := loadTemplate(templateFile)
 
 	// 切换到 gin 文件所在的目录
 	originalDir, err := os.Getwd()
@@ -118,6 +115,9 @@ func runGen(templateFile, serviceOutputDir, middlewareOutputDir string) {
 	if middlewareOutputDir != "" {
 		template.Options.GenerateMiddleware = true
 		template.Options.MiddlewareOutputDir = middlewareOutputDir
+		template.Options.PruneMiddleware = prune
+	} else if prune {
+		fmt.Println("警告: --prune 需要与 -m 一起使用，已忽略")
 	}
 
 	// 生成代码
@@ -129,6 +129,145 @@ func runGen(templateFile, serviceOutputDir, middlewareOutputDir string) {
 	fmt.Printf("代码生成成功! 输出目录: %s, 包名: %s\n", template.Options.OutputDir, template.Options.PackageName)
 }
 
+// loadTemplate 解析模板及其导入的文件并做语义检查，一次输出全部错误和警告，有错误时退出
+func loadTemplate(templateFile string) *parser.GinTemplate {
+	// 读取文件内容
+	content, err := os.ReadFile(templateFile)
+	if err != nil {
+		log.Fatalf("读取模板文件失败: %v", err)
+	}
+
+	file, imports, diags := parser.LoadFile(templateFile, string(content))
+	var template *parser.GinTemplate
+	if !diags.HasErrors() {
+		// 语法正确时再做语义检查
+		template = parser.BuildTemplate(file, imports...)
+		diags = append(diags, checker.Check(template)...)
+		diags.Sort()
+	}
+	if len(diags) > 0 {
+		parser.WriteDiagnostics(os.Stderr, diags, map[string]string{templateFile: string(content)})
+	}
+	if diags.HasErrors() {
+		fmt.Fprintf(os.Stderr, "解析模板失败: %s\n", templateFile)
+		os.Exit(1)
+	}
+	return template
+}
+
+// OpenAPICommand 导出 OpenAPI 文档命令
+func OpenAPICommand() *cobra.Command {
+	var (
+		templateFile string
+		outputFile   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "openapi",
+		Short: "导出 OpenAPI 文档",
+		Long:  "根据 .gin 模板文件导出 OpenAPI 3.1 文档，输出文件以 .json 结尾时为 JSON，否则为 YAML",
+		Run: func(cmd *cobra.Command, args []string) {
+			runOpenAPI(templateFile, outputFile)
+		},
+	}
+
+	cmd.Flags().StringVarP(&templateFile, "file", "f", "", "模板文件路径 (.gin 文件)")
+	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "输出文件路径（默认输出到标准输出）")
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// runOpenAPI 执行导出 OpenAPI 文档命令
+func runOpenAPI(templateFile, outputFile string) {
+	if _, err := os.Stat(templateFile); os.IsNotExist(err) {
+		log.Fatalf("模板文件不存在: %s", templateFile)
+	}
+
+	doc := openapi.Build(loadTemplate(templateFile))
+
+	var data []byte
+	var err error
+	if strings.HasSuffix(outputFile, ".json") {
+		data, err = doc.JSON()
+	} else {
+		data, err = doc.YAML()
+	}
+	if err != nil {
+		log.Fatalf("生成 OpenAPI 文档失败: %v", err)
+	}
+
+	if outputFile == "" {
+		os.Stdout.Write(data)
+		return
+	}
+	if err := writeOutputFile(outputFile, data); err != nil {
+		log.Fatalf("写入 OpenAPI 文档失败: %v", err)
+	}
+	fmt.Printf("OpenAPI 文档生成成功: %s\n", outputFile)
+}
+
+// writeOutputFile 写入输出文件，目录不存在时先创建
+func writeOutputFile(outputFile string, data []byte) error {
+	if dir := filepath.Dir(outputFile); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("创建目录失败: %w", err)
+		}
+	}
+	return os.WriteFile(outputFile, data, 0644)
+}
+
+// ClientCommand 生成客户端命令
+func ClientCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "client",
+		Short: "生成调用接口的客户端代码",
+	}
+	cmd.AddCommand(clientTSCommand())
+	return cmd
+}
+
+// clientTSCommand 生成 TypeScript 客户端命令
+func clientTSCommand() *cobra.Command {
+	var (
+		templateFile string
+		outputFile   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "ts",
+		Short: "生成 TypeScript 客户端",
+		Long:  "根据 .gin 模板文件生成 TypeScript 类型定义和基于 fetch 的请求函数",
+		Run: func(cmd *cobra.Command, args []string) {
+			runClientTS(templateFile, outputFile)
+		},
+	}
+
+	cmd.Flags().StringVarP(&templateFile, "file", "f", "", "模板文件路径 (.gin 文件)")
+	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "输出文件路径（默认输出到标准输出）")
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// runClientTS 执行生成 TypeScript 客户端命令
+func runClientTS(templateFile, outputFile string) {
+	if _, err := os.Stat(templateFile); os.IsNotExist(err) {
+		log.Fatalf("模板文件不存在: %s", templateFile)
+	}
+
+	data := []byte(client.TypeScript(loadTemplate(templateFile)))
+
+	if outputFile == "" {
+		os.Stdout.Write(data)
+		return
+	}
+	if err := writeOutputFile(outputFile, data); err != nil {
+		log.Fatalf("写入 TypeScript 客户端失败: %v", err)
+	}
+	fmt.Printf("TypeScript 客户端生成成功: %s\n", outputFile)
+}
+
 // runNew 执行新建命令
 func runNew(name, outputPath string) {
 	var templateContent string
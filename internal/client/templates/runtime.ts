@@ -0,0 +1,106 @@
+/** 客户端配置 */
+export interface ClientConfig {
+  /** 接口地址，如 https://api.example.com，默认为当前站点 */
+  baseURL?: string;
+  /** 每个请求都会携带的请求头 */
+  headers?: Record<string, string>;
+  /** 自定义 fetch 实现 */
+  fetch?: typeof fetch;
+}
+
+/** 单个请求的配置，覆盖全局配置 */
+export interface RequestOptions extends ClientConfig {
+  signal?: AbortSignal;
+}
+
+const clientConfig: ClientConfig = {};
+
+/** 设置全局客户端配置 */
+export function configure(config: ClientConfig): void {
+  Object.assign(clientConfig, config);
+}
+
+/** 接口返回非 2xx 状态码时抛出的错误，body 为解析后的响应内容 */
+export class APIError extends Error {
+  readonly status: number;
+  readonly body: unknown;
+
+  constructor(status: number, body: unknown) {
+    const message =
+      typeof body === "object" && body !== null && "message" in body
+        ? String((body as { message: unknown }).message)
+        : `HTTP ${status}`;
+    super(message);
+    this.name = "APIError";
+    this.status = status;
+    this.body = body;
+  }
+}
+
+type Query = Record<string, unknown>;
+
+function pathParam(value: unknown): string {
+  return encodeURIComponent(String(value));
+}
+
+function wildcardParam(value: unknown): string {
+  return String(value).replace(/^\/+/, "").split("/").map(encodeURIComponent).join("/");
+}
+
+function queryString(query: Query | undefined): string {
+  if (!query) {
+    return "";
+  }
+  const params = new URLSearchParams();
+  for (const [key, value] of Object.entries(query)) {
+    for (const item of Array.isArray(value) ? value : [value]) {
+      if (item !== undefined && item !== null) {
+        params.append(key, String(item));
+      }
+    }
+  }
+  const encoded = params.toString();
+  return encoded ? `?${encoded}` : "";
+}
+
+async function request<T>(
+  method: string,
+  path: string,
+  query: Query | undefined,
+  requestHeaders: Query | undefined,
+  body: unknown,
+  options: RequestOptions = {},
+): Promise<T> {
+  const baseURL = (options.baseURL ?? clientConfig.baseURL ?? "").replace(/\/+$/, "");
+  const headers: Record<string, string> = {
+    Accept: "application/json",
+    ...clientConfig.headers,
+    ...options.headers,
+  };
+  for (const [key, value] of Object.entries(requestHeaders ?? {})) {
+    if (value !== undefined && value !== null) {
+      headers[key] = String(value);
+    }
+  }
+  const init: RequestInit = { method, headers, signal: options.signal };
+  if (body !== undefined) {
+    headers["Content-Type"] = "application/json";
+    init.body = JSON.stringify(body);
+  }
+
+  const doFetch = options.fetch ?? clientConfig.fetch ?? fetch;
+  const response = await doFetch(baseURL + path + queryString(query), init);
+  const text = await response.text();
+  let data: unknown = undefined;
+  if (text) {
+    try {
+      data = JSON.parse(text);
+    } catch {
+      data = text;
+    }
+  }
+  if (!response.ok) {
+    throw new APIError(response.status, data);
+  }
+  return data as T;
+}
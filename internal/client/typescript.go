@@ -0,0 +1,473 @@
+package client
+
+import (
+	_ "embed"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/YuukiKazuto/kratosgin/internal/parser"
+)
+
+//go:embed templates/runtime.ts
+var typeScriptRuntime string
+
+// tsGenerator 根据模板生成 TypeScript 客户端
+type tsGenerator struct {
+	template *parser.GinTemplate
+	types    map[string]parser.Type
+	enums    map[string]bool
+	envelope string // 响应包装类型名，生成为以响应数据类型为参数的泛型 interface
+}
+
+// tsProperty 展开嵌入字段后的一个属性
+type tsProperty struct {
+	Name   string
+	Field  parser.Field
+	depth  int
+	hidden bool // 字段不在 JSON 中（json:"-"），只通过 uri、header、form 绑定
+}
+
+// TypeScript 根据 gin 模板生成 TypeScript 客户端
+// 每个枚举生成同名的常量对象和取值的联合类型，每个类型生成一个 interface（类型别名保持为 type），每个方法生成一个 async 函数
+func TypeScript(template *parser.GinTemplate) string {
+	types := append([]parser.Type(nil), template.Types...)
+	envelope, hasEnvelope := template.EnvelopeType()
+	if template.Options.StandardEnvelope() {
+		// 内置的响应包装类型不在 .gin 文件中定义
+		types = append(types, envelope)
+	}
+
+	g := &tsGenerator{template: template, types: make(map[string]parser.Type), enums: make(map[string]bool)}
+	if hasEnvelope {
+		g.envelope = envelope.Name
+	}
+	for _, t := range types {
+		if _, ok := g.types[t.Name]; !ok {
+			g.types[t.Name] = t
+		}
+	}
+
+	var result strings.Builder
+	result.WriteString("// Code generated by kratosgin. DO NOT EDIT.\n")
+	result.WriteString("/* eslint-disable */\n\n")
+	result.WriteString(typeScriptRuntime)
+
+	written := make(map[string]bool)
+	for _, e := range template.Enums {
+		if written[e.Name] {
+			continue
+		}
+		written[e.Name] = true
+		g.enums[e.Name] = true
+		result.WriteString("\n")
+		writeEnum(&result, e)
+	}
+	for _, t := range types {
+		if written[t.Name] {
+			continue
+		}
+		written[t.Name] = true
+		result.WriteString("\n")
+		g.writeType(&result, t)
+	}
+
+	routes := template.Routes()
+	names := functionNames(routes)
+	for i, route := range routes {
+		result.WriteString("\n")
+		g.writeFunction(&result, names[i], route)
+	}
+	return result.String()
+}
+
+// writeType 生成类型定义
+func (g *tsGenerator) writeType(result *strings.Builder, t parser.Type) {
+	writeDoc(result, "", t.Comment)
+	if t.IsAlias {
+		result.WriteString(fmt.Sprintf("export type %s = %s;\n", t.Name, g.tsType(t.AliasTo)))
+		return
+	}
+
+	// 响应包装的数据字段类型为泛型参数 T
+	var data parser.Field
+	if t.Name == g.envelope {
+		if fields := t.EnvelopeDataFields(); len(fields) > 0 {
+			data = fields[0]
+			result.WriteString(fmt.Sprintf("export interface %s<T = unknown> {\n", t.Name))
+		}
+	}
+	if data.Name == "" {
+		result.WriteString(fmt.Sprintf("export interface %s {\n", t.Name))
+	}
+	for _, property := range g.properties(t.Name) {
+		writeDoc(result, "  ", property.Field.Comment)
+		optional := ""
+		if strings.Contains(reflect.StructTag(property.Field.Tag).Get("json"), ",omitempty") {
+			optional = "?"
+		}
+		typ := g.tsType(property.Field.Type)
+		if strings.HasPrefix(strings.TrimSpace(property.Field.Type), "*") {
+			typ += " | null"
+		}
+		if data.Name != "" && property.depth == 0 && property.Field.Name == data.Name {
+			typ = "T"
+		}
+		result.WriteString(fmt.Sprintf("  %s%s: %s;\n", propertyName(property.Name), optional, typ))
+	}
+	result.WriteString("}\n")
+}
+
+// writeEnum 生成枚举的常量对象和同名的取值联合类型
+func writeEnum(result *strings.Builder, e parser.Enum) {
+	writeDoc(result, "", e.Comment)
+	result.WriteString(fmt.Sprintf("export const %s = {\n", e.Name))
+	for _, v := range e.Values {
+		writeDoc(result, "  ", v.Comment)
+		result.WriteString(fmt.Sprintf("  %s: %s,\n", propertyName(v.Name), v.Literal()))
+	}
+	result.WriteString("} as const;\n")
+	writeDoc(result, "", e.Comment)
+	result.WriteString(fmt.Sprintf("export type %s = (typeof %s)[keyof typeof %s];\n", e.Name, e.Name, e.Name))
+}
+
+// properties 返回类型的属性，没有 json 名称的嵌入字段被展开
+// 属性包括序列化为 JSON 的字段，以及不在 JSON 中但通过 uri、header、form 标签绑定的字段（以字段名为属性名）
+// 同名属性按 encoding/json 的规则保留嵌入层级最浅的一个
+func (g *tsGenerator) properties(typeName string) []tsProperty {
+	var properties []tsProperty
+	seen := make(map[string]bool)
+
+	var collect func(name string, depth int)
+	collect = func(name string, depth int) {
+		name = strings.TrimPrefix(strings.TrimSpace(name), "*")
+		t, ok := g.types[name]
+		if !ok || seen[name] {
+			return
+		}
+		seen[name] = true
+		defer delete(seen, name)
+
+		if t.IsAlias {
+			collect(t.AliasTo, depth)
+			return
+		}
+		for _, field := range t.Fields {
+			jsonName := field.TagName("json")
+			if jsonName == "-" {
+				if field.Name != "" && isBound(field) {
+					properties = append(properties, tsProperty{Name: field.Name, Field: field, depth: depth, hidden: true})
+				}
+				continue
+			}
+			if field.Name == "" && jsonName == "" {
+				collect(field.Type, depth+1)
+				continue
+			}
+			if jsonName == "" {
+				jsonName = field.Name
+			}
+			properties = append(properties, tsProperty{Name: jsonName, Field: field, depth: depth})
+		}
+	}
+	collect(typeName, 0)
+
+	// 同名属性保留层级最浅的一个
+	shallowest := make(map[string]int)
+	for _, property := range properties {
+		if depth, ok := shallowest[property.Name]; !ok || property.depth < depth {
+			shallowest[property.Name] = property.depth
+		}
+	}
+	var result []tsProperty
+	added := make(map[string]bool)
+	for _, property := range properties {
+		if added[property.Name] || property.depth != shallowest[property.Name] {
+			continue
+		}
+		added[property.Name] = true
+		result = append(result, property)
+	}
+	return result
+}
+
+// isBound 判断字段是否通过 uri、header 或 form 标签绑定
+func isBound(field parser.Field) bool {
+	for _, key := range []string{"uri", "header", "form"} {
+		if name := field.TagName(key); name != "" && name != "-" {
+			return true
+		}
+	}
+	return false
+}
+
+// tsType 将 Go 类型表达式映射为 TypeScript 类型
+func (g *tsGenerator) tsType(goType string) string {
+	goType = strings.TrimPrefix(strings.TrimSpace(goType), "*")
+
+	switch {
+	case goType == "[]byte":
+		// encoding/json 将 []byte 编码为 base64 字符串
+		return "string"
+	case strings.HasPrefix(goType, "[]"):
+		return arrayType(g.tsType(goType[2:]))
+	case strings.HasPrefix(goType, "["):
+		if end := strings.Index(goType, "]"); end > 0 {
+			return arrayType(g.tsType(goType[end+1:]))
+		}
+	case strings.HasPrefix(goType, "map["):
+		if end := matchingBracket(goType, len("map")); end > 0 {
+			return fmt.Sprintf("Record<string, %s>", g.tsType(goType[end+1:]))
+		}
+	}
+
+	switch goType {
+	case "string", "time.Time":
+		return "string"
+	case "bool":
+		return "boolean"
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64",
+		"byte", "rune", "float32", "float64", "time.Duration":
+		return "number"
+	}
+
+	if _, ok := g.types[goType]; ok {
+		return goType
+	}
+	if g.enums[goType] {
+		return goType
+	}
+	// interface{}、any 以及外部包的类型
+	return "unknown"
+}
+
+// arrayType 生成数组类型，联合类型需要加括号
+func arrayType(elem string) string {
+	if strings.Contains(elem, " ") {
+		return "Array<" + elem + ">"
+	}
+	return elem + "[]"
+}
+
+// matchingBracket 返回 s[open] 处的 [ 对应的 ] 的位置
+func matchingBracket(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// writeFunction 生成一个方法对应的请求函数，参数的位置与生成的处理器绑定规则一致
+// 路径参数取请求中 uri 标签或属性名相同的字段，没有对应字段时作为单独的参数；
+// 带 header 标签的字段作为请求头；GET 请求的其余字段作为查询参数，其他方法只有带 form 标签的字段作为查询参数，
+// 并将请求去掉不在 JSON 中的字段后作为 JSON 请求体发送
+func (g *tsGenerator) writeFunction(result *strings.Builder, name string, route parser.Route) {
+	properties := g.properties(route.Request)
+	hasBody := parser.HasBody(route.HTTPMethod)
+
+	var extraParams []string
+	pathParams := make(map[string]bool)
+	path := strings.Split(route.FullPath, "/")
+	for i, segment := range path {
+		if !strings.HasPrefix(segment, ":") && !strings.HasPrefix(segment, "*") {
+			continue
+		}
+		param := segment[1:]
+		pathParams[param] = true
+
+		value := ""
+		for _, property := range properties {
+			if property.Field.TagName("uri") == param || property.Name == param {
+				value = "req" + propertyAccess(property.Name)
+				break
+			}
+		}
+		if value == "" {
+			value = identifier(param)
+			extraParams = append(extraParams, value+": string | number")
+		}
+		if segment[0] == '*' {
+			path[i] = "${wildcardParam(" + value + ")}"
+		} else {
+			path[i] = "${pathParam(" + value + ")}"
+		}
+	}
+
+	var queryEntries, headerEntries []string
+	for _, property := range properties {
+		if pathParams[property.Field.TagName("uri")] || pathParams[property.Name] {
+			continue
+		}
+		value := "req" + propertyAccess(property.Name)
+		if header := property.Field.TagName("header"); header != "" && header != "-" {
+			headerEntries = append(headerEntries, fmt.Sprintf("%s: %s", propertyName(header), value))
+			continue
+		}
+		key := property.Field.TagName("form")
+		if key == "-" || (hasBody && key == "") {
+			continue
+		}
+		if key == "" {
+			key = property.Field.Name
+		}
+		queryEntries = append(queryEntries, fmt.Sprintf("%s: %s", propertyName(key), value))
+	}
+	query, headers, body := objectLiteral(queryEntries), objectLiteral(headerEntries), "undefined"
+	var hidden []string
+	if hasBody && route.HasRequest() {
+		body = "req"
+		for _, property := range properties {
+			if property.hidden {
+				hidden = append(hidden, fmt.Sprintf("%s: _%s", propertyName(property.Name), identifier(property.Name)))
+			}
+		}
+		if len(hidden) > 0 {
+			body = "body"
+		}
+	}
+
+	description := route.Description
+	if description == "" {
+		description = route.Service + "." + strings.Title(route.Name)
+	}
+	method := strings.ToUpper(route.HTTPMethod)
+	writeDoc(result, "", fmt.Sprintf("%s\n%s %s", description, method, route.FullPath))
+
+	params := extraParams
+	if route.HasRequest() {
+		params = append(params, "req: "+g.tsType(route.Request))
+	}
+	params = append(params, "options?: RequestOptions")
+
+	// 没有响应类型或状态码为 204 时没有响应体，使用响应包装时返回包装后的响应
+	response := "void"
+	switch {
+	case route.StatusCode() == http.StatusNoContent:
+	case g.envelope != "" && route.HasResponse():
+		response = fmt.Sprintf("%s<%s>", g.envelope, g.tsType(route.Response))
+	case g.envelope != "":
+		response = g.envelope
+	case route.HasResponse():
+		response = g.tsType(route.Response)
+	}
+	result.WriteString(fmt.Sprintf("export async function %s(%s): Promise<%s> {\n", name, strings.Join(params, ", "), response))
+	if len(hidden) > 0 {
+		result.WriteString(fmt.Sprintf("  const { %s, ...body } = req;\n", strings.Join(hidden, ", ")))
+	}
+	result.WriteString(fmt.Sprintf("  return request<%s>(%q, `%s`, %s, %s, %s, options);\n", response, method, strings.Join(path, "/"), query, headers, body))
+	result.WriteString("}\n")
+}
+
+// objectLiteral 生成对象字面量，没有属性时为 undefined
+func objectLiteral(entries []string) string {
+	if len(entries) == 0 {
+		return "undefined"
+	}
+	return "{ " + strings.Join(entries, ", ") + " }"
+}
+
+// functionNames 返回每条路由的函数名，默认为方法名的小驼峰形式
+// 不同服务中存在同名方法时，这些方法的函数名加上服务名前缀
+func functionNames(routes []parser.Route) []string {
+	services := make(map[string]map[string]bool)
+	for _, route := range routes {
+		name := lowerFirst(route.Name)
+		if services[name] == nil {
+			services[name] = make(map[string]bool)
+		}
+		services[name][route.Service] = true
+	}
+
+	names := make([]string, len(routes))
+	for i, route := range routes {
+		name := lowerFirst(route.Name)
+		if len(services[name]) > 1 {
+			name = lowerFirst(strings.TrimSuffix(route.Service, "Service")) + strings.Title(route.Name)
+		}
+		names[i] = name
+	}
+	return names
+}
+
+// writeDoc 生成 JSDoc 注释
+func writeDoc(result *strings.Builder, indent, comment string) {
+	comment = strings.TrimSpace(comment)
+	if comment == "" {
+		return
+	}
+	lines := strings.Split(strings.ReplaceAll(comment, "*/", "* /"), "\n")
+	if len(lines) == 1 {
+		result.WriteString(fmt.Sprintf("%s/** %s */\n", indent, lines[0]))
+		return
+	}
+	result.WriteString(indent + "/**\n")
+	for _, line := range lines {
+		result.WriteString(fmt.Sprintf("%s * %s\n", indent, strings.TrimSpace(line)))
+	}
+	result.WriteString(indent + " */\n")
+}
+
+// isIdentifier 判断名称能否直接作为 JavaScript 属性名或变量名
+func isIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		if r == '_' || r == '$' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (i > 0 && r >= '0' && r <= '9') {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// propertyName 返回对象字面量和接口中的属性名，不是合法标识符时加引号
+func propertyName(name string) string {
+	if isIdentifier(name) {
+		return name
+	}
+	return fmt.Sprintf("%q", name)
+}
+
+// propertyAccess 返回读取属性的表达式
+func propertyAccess(name string) string {
+	if isIdentifier(name) {
+		return "." + name
+	}
+	return fmt.Sprintf("[%q]", name)
+}
+
+// identifier 将路径参数名转换为合法的变量名
+func identifier(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if r == '_' || r == '$' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	if !isIdentifier(b.String()) {
+		return "_" + b.String()
+	}
+	return b.String()
+}
+
+// lowerFirst 将首字母转换为小写
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
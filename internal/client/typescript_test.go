@@ -0,0 +1,122 @@
+package client
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/YuukiKazuto/kratosgin/internal/parser"
+)
+
+// generateSource 解析 .gin 源码并生成 TypeScript 客户端，解析出错时测试失败
+func generateSource(t *testing.T, source string) string {
+	t.Helper()
+	file, diags := parser.ParseFile("test.gin", `options {
+	packageName: v1
+	outputDir: .
+}
+`+source)
+	if diags.HasErrors() {
+		t.Fatalf("解析失败:\n%v", diags)
+	}
+	return TypeScript(parser.BuildTemplate(file))
+}
+
+func TestTypeScriptRequestBinding(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "不在 JSON 中的请求头",
+			source: `type (
+	CreateReq {
+		Token string ` + "`json:\"-\" header:\"X-Token\" binding:\"required\"`" + `
+		Name string ` + "`json:\"name\"`" + `
+	}
+	CreateResp {}
+)
+service ItemService {
+	@Create POST /items CreateReq CreateResp
+}
+`,
+			want: []string{
+				"export interface CreateReq {\n  Token: string;\n  name: string;\n}",
+				"  const { Token: _Token, ...body } = req;\n",
+				"`/items`, undefined, { \"X-Token\": req.Token }, body, options);",
+			},
+		},
+		{
+			name: "JSON 中的请求头",
+			source: `type (
+	ListReq {
+		Token string ` + "`header:\"X-Token\"`" + `
+		Page int ` + "`form:\"page\"`" + `
+	}
+	ListResp {}
+)
+service ItemService {
+	@List GET /items ListReq ListResp
+}
+`,
+			want:    []string{"`/items`, { page: req.Page }, { \"X-Token\": req.Token }, undefined, options);"},
+			notWant: []string{"...body"},
+		},
+		{
+			name: "不在 JSON 中的路径参数和查询参数",
+			source: `type (
+	UpdateReq {
+		ID int ` + "`json:\"-\" uri:\"id\"`" + `
+		DryRun bool ` + "`json:\"-\" form:\"dry_run\"`" + `
+		Name string ` + "`json:\"name\"`" + `
+	}
+	UpdateResp {}
+)
+service ItemService {
+	@Update PUT /items/:id UpdateReq UpdateResp
+}
+`,
+			want: []string{
+				"export interface UpdateReq {\n  ID: number;\n  DryRun: boolean;\n  name: string;\n}",
+				"export async function update(req: UpdateReq, options?: RequestOptions)",
+				"  const { ID: _ID, DryRun: _DryRun, ...body } = req;\n",
+				"`/items/${pathParam(req.ID)}`, { dry_run: req.DryRun }, undefined, body, options);",
+			},
+		},
+		{
+			name: "不在 JSON 中且没有绑定标签的字段",
+			source: `type (
+	GetReq {
+		Internal string ` + "`json:\"-\"`" + `
+		Name string ` + "`json:\"name\"`" + `
+	}
+	GetResp {}
+)
+service ItemService {
+	@Get POST /items/get GetReq GetResp
+}
+`,
+			want:    []string{"export interface GetReq {\n  name: string;\n}", "undefined, undefined, req, options);"},
+			notWant: []string{"Internal"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := generateSource(t, tt.source)
+			// 失败时只输出运行时之后生成的代码
+			generated := got[strings.Index(got, typeScriptRuntime)+len(typeScriptRuntime):]
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("生成结果缺少 %q:\n%s", want, generated)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(got, notWant) {
+					t.Errorf("生成结果不应包含 %q:\n%s", notWant, generated)
+				}
+			}
+		})
+	}
+}
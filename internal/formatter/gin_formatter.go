@@ -3,10 +3,13 @@ package formatter
 import (
 	"fmt"
 	"os"
+	"reflect"
 	"strings"
+
+	"github.com/YuukiKazuto/kratosgin/internal/parser"
 )
 
-// FormatGinFile 格式化 gin 文件
+// FormatGinFile 格式化 gin 文件，原文件有语法错误或格式化会改变文件的含义时返回错误，不修改文件
 func FormatGinFile(filePath string) error {
 	// 读取文件内容
 	content, err := os.ReadFile(filePath)
@@ -15,7 +18,13 @@ func FormatGinFile(filePath string) error {
 	}
 
 	// 格式化内容
-	formattedContent := formatContent(string(content))
+	formattedContent, err := format(filePath, string(content))
+	if err != nil {
+		return err
+	}
+	if formattedContent == string(content) {
+		return nil
+	}
 
 	// 写回文件
 	if err := os.WriteFile(filePath, []byte(formattedContent), 0644); err != nil {
@@ -25,16 +34,71 @@ func FormatGinFile(filePath string) error {
 	return nil
 }
 
+// format 格式化文件内容，并重新解析格式化的结果，确认与原文件的语法树相同
+// formatContent 按行处理，无法正确处理写在同一行中的代码块内容和以分号分隔的多项，这时不做修改并返回错误
+func format(filename, content string) (string, error) {
+	original, diags := parser.ParseFile(filename, content)
+	if err := diags.Err(); err != nil {
+		return "", fmt.Errorf("文件存在语法错误，未格式化:\n%w", err)
+	}
+
+	formatted := formatContent(content)
+	result, diags := parser.ParseFile(filename, formatted)
+	if diags.HasErrors() || !sameSyntax(reflect.ValueOf(original), reflect.ValueOf(result)) {
+		return "", fmt.Errorf("格式化会改变文件 %s 的含义，未格式化: 请将 '{' 之后的内容、以分号分隔的多项和 '}' 分别写在单独的行中", filename)
+	}
+	return formatted, nil
+}
+
+// posType 语法树中的位置类型，比较语法树时忽略
+var posType = reflect.TypeOf(parser.Pos{})
+
+// sameSyntax 比较两个语法树节点，忽略位置信息
+func sameSyntax(a, b reflect.Value) bool {
+	if a.Type() == posType {
+		return true
+	}
+	switch a.Kind() {
+	case reflect.Ptr:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		return sameSyntax(a.Elem(), b.Elem())
+	case reflect.Slice:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !sameSyntax(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if !sameSyntax(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a.Interface() == b.Interface()
+	}
+}
+
 // formatContent 格式化文件内容
 func formatContent(content string) string {
 	lines := strings.Split(content, "\n")
 	var formattedLines []string
 
+	inImport := false
 	inOptions := false
+	inValidators := false
+	inEnum := false
 	inType := false
 	inTypeGroup := false
-	inService := false
-	inGroup := false
+	inService := false // 在 service 或顶级分组中
+	groupDepth := 0    // service 或顶级分组中子分组的嵌套层数
 
 	for _, line := range lines {
 		originalLine := line
@@ -51,21 +115,40 @@ func formatContent(content string) string {
 		if strings.HasPrefix(line, "//") {
 			// 根据当前状态确定注释的缩进
 			var indent string
-			if inTypeGroup {
+			if inTypeGroup || inValidators || inEnum {
 				indent = "\t"
 			} else if inType {
 				indent = "\t"
 			} else if inService {
-				indent = "\t"
-			} else if inGroup {
-				indent = "\t\t"
+				indent = strings.Repeat("\t", groupDepth+1)
 			}
 			formattedLines = append(formattedLines, indent+line)
 			continue
 		}
 
-		// 处理 options 块
+		// 处理 import ( ) 组
+		if inImport {
+			if line == ")" {
+				inImport = false
+				formattedLines = append(formattedLines, ")")
+				continue
+			}
+			formattedLines = append(formattedLines, "\t"+line)
+			continue
+		}
+
+		if strings.HasPrefix(line, "import") && strings.HasSuffix(line, "(") {
+			inImport = true
+			formattedLines = append(formattedLines, "import (")
+			continue
+		}
+
+		// 处理 options 块，写在一行中的块保持原样
 		if strings.HasPrefix(line, "options") {
+			if isOneLineBlock(line) {
+				formattedLines = append(formattedLines, line)
+				continue
+			}
 			inOptions = true
 			// 格式化 options 行，确保 { 前有空格
 			optionsLine := strings.TrimSpace(line)
@@ -90,6 +173,56 @@ func formatContent(content string) string {
 			continue
 		}
 
+		// 处理 validators 块，每条规则单层缩进，写在一行中的块保持原样
+		if !inType && !inTypeGroup && !inService && strings.HasPrefix(line, "validators") && strings.Contains(line, "{") {
+			formattedLines = append(formattedLines, "")
+			if strings.Contains(line, "}") {
+				formattedLines = append(formattedLines, line)
+				continue
+			}
+			inValidators = true
+			formattedLines = append(formattedLines, "validators {")
+			continue
+		}
+
+		if inValidators {
+			if line == "}" {
+				inValidators = false
+				formattedLines = append(formattedLines, "}")
+				continue
+			}
+			formattedLines = append(formattedLines, "\t"+line)
+			continue
+		}
+
+		// 处理 enum 定义，每个取值单层缩进，写在一行中的枚举保持原样
+		if !inType && !inTypeGroup && !inService && strings.HasPrefix(line, "enum ") && strings.Contains(line, "{") {
+			// 已有空行或上方是枚举的文档注释时不插入空行
+			if n := len(formattedLines); n > 0 && strings.TrimSpace(formattedLines[n-1]) != "" && !strings.HasPrefix(strings.TrimSpace(formattedLines[n-1]), "//") {
+				formattedLines = append(formattedLines, "")
+			}
+			if strings.Contains(line, "}") {
+				formattedLines = append(formattedLines, line)
+				continue
+			}
+			inEnum = true
+			if !strings.Contains(line, " {") {
+				line = strings.Replace(line, "{", " {", 1)
+			}
+			formattedLines = append(formattedLines, line)
+			continue
+		}
+
+		if inEnum {
+			if line == "}" {
+				inEnum = false
+				formattedLines = append(formattedLines, "}")
+				continue
+			}
+			formattedLines = append(formattedLines, "\t"+line)
+			continue
+		}
+
 		// 处理 type 定义
 		if strings.HasPrefix(line, "type ") || strings.HasPrefix(line, "type(") {
 			// 检查是否是 type ( ) 格式
@@ -131,8 +264,13 @@ func formatContent(content string) string {
 				}
 				continue
 			} else {
-				inType = true
 				formattedLines = append(formattedLines, "")
+				// 写在一行中的结构体保持原样
+				if isOneLineBlock(line) {
+					formattedLines = append(formattedLines, line)
+					continue
+				}
+				inType = true
 				// 格式化 type 行，确保 { 前有空格
 				typeLine := strings.TrimSpace(line)
 				if strings.Contains(typeLine, "{") && !strings.Contains(typeLine, " {") {
@@ -205,10 +343,14 @@ func formatContent(content string) string {
 			continue
 		}
 
-		// 处理 service 定义
-		if strings.HasPrefix(line, "service ") {
-			inService = true
+		// 处理 service 定义，顶级分组与 service 的缩进方式相同
+		if strings.HasPrefix(line, "service ") || (!inService && strings.HasPrefix(line, "group ")) {
 			formattedLines = append(formattedLines, "")
+			if isOneLineBlock(line) {
+				formattedLines = append(formattedLines, line)
+				continue
+			}
+			inService = true
 			// 格式化 service 行，确保 { 前有空格
 			serviceLine := strings.TrimSpace(line)
 			if strings.Contains(serviceLine, "{") && !strings.Contains(serviceLine, " {") {
@@ -219,28 +361,35 @@ func formatContent(content string) string {
 		}
 
 		if inService {
-			// 先检查是否在 group 中
-			if inGroup {
-				if line == "}" {
-					inGroup = false
-					formattedLines = append(formattedLines, "\t}")
+			// 处理 group 定义，group 可以嵌套
+			if strings.HasPrefix(line, "group ") {
+				// 紧跟在 { 之后时不插入空行
+				if n := len(formattedLines); !strings.HasSuffix(formattedLines[n-1], "{") {
+					formattedLines = append(formattedLines, "")
+				}
+				if isOneLineBlock(line) {
+					formattedLines = append(formattedLines, strings.Repeat("\t", groupDepth+1)+line)
 					continue
 				}
-				// group 内容缩进
-				formattedLines = append(formattedLines, "\t\t"+line)
-				continue
-			}
-
-			// 处理 group 定义
-			if strings.HasPrefix(line, "group ") {
-				inGroup = true
-				formattedLines = append(formattedLines, "")
+				groupDepth++
 				// 格式化 group 行，确保 { 前有空格
 				groupLine := strings.TrimSpace(line)
 				if strings.Contains(groupLine, "{") && !strings.Contains(groupLine, " {") {
 					groupLine = strings.Replace(groupLine, "{", " {", 1)
 				}
-				formattedLines = append(formattedLines, "\t"+groupLine)
+				formattedLines = append(formattedLines, strings.Repeat("\t", groupDepth)+groupLine)
+				continue
+			}
+
+			// 在 group 中
+			if groupDepth > 0 {
+				if line == "}" {
+					formattedLines = append(formattedLines, strings.Repeat("\t", groupDepth)+"}")
+					groupDepth--
+					continue
+				}
+				// group 内容缩进
+				formattedLines = append(formattedLines, strings.Repeat("\t", groupDepth+1)+line)
 				continue
 			}
 
@@ -263,33 +412,30 @@ func formatContent(content string) string {
 	return cleanupEmptyLines(strings.Join(formattedLines, "\n"))
 }
 
-// cleanupEmptyLines 清理多余的空行
+// isOneLineBlock 判断代码块是否写在一行中，即同时包含 '{' 和 '}'
+func isOneLineBlock(line string) bool {
+	return strings.Contains(line, "{") && strings.Contains(line, "}")
+}
+
+// cleanupEmptyLines 将连续的空行合并为一个，并去掉文件开头的空行
 func cleanupEmptyLines(content string) string {
 	lines := strings.Split(content, "\n")
 	var cleanedLines []string
 
-	for i, line := range lines {
-		// 如果是空行
+	for _, line := range lines {
 		if strings.TrimSpace(line) == "" {
-			// 如果前一行也是空行，跳过
-			if i > 0 && strings.TrimSpace(lines[i-1]) == "" {
-				continue
-			}
-			// 如果下一行是空行，跳过
-			if i < len(lines)-1 && strings.TrimSpace(lines[i+1]) == "" {
+			// 文件开头或前一行也是空行时跳过
+			if n := len(cleanedLines); n == 0 || cleanedLines[n-1] == "" {
 				continue
 			}
+			line = ""
 		}
 		cleanedLines = append(cleanedLines, line)
 	}
 
 	// 确保文件以单个换行符结尾
-	result := strings.Join(cleanedLines, "\n")
-	if !strings.HasSuffix(result, "\n") {
-		result += "\n"
-	}
-
-	return result
+	result := strings.TrimRight(strings.Join(cleanedLines, "\n"), "\n")
+	return result + "\n"
 }
 
 // formatOptionsLine 格式化 options 行
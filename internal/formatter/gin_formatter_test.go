@@ -0,0 +1,187 @@
+package formatter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatContent(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "保留 options 和 type 之间的空行",
+			in: `options {
+packageName:v1
+}
+
+type Req {
+ID int
+}
+`,
+			want: `options {
+	packageName: v1
+}
+
+type Req {
+	ID int
+}
+`,
+		},
+		{
+			name: "合并连续的空行",
+			in: `options {
+	packageName: v1
+}
+
+
+
+@Ping GET /ping - -
+
+
+`,
+			want: `options {
+	packageName: v1
+}
+
+@Ping GET /ping - -
+`,
+		},
+		{
+			name: "顶级分组和子分组",
+			in: `group @admin /admin{
+middleware: ["auth"]
+@Get GET /items/:id Req -
+group /sub {
+@List GET /list - -
+}
+}
+@Ping GET /ping - -
+`,
+			want: `group @admin /admin {
+	middleware: ["auth"]
+	@Get GET /items/:id Req -
+
+	group /sub {
+		@List GET /list - -
+	}
+}
+@Ping GET /ping - -
+`,
+		},
+		{
+			name: "service 中的分组",
+			in: `service S {
+	middleware: ["auth"]
+  group /a {
+  // 注释
+  @Get GET /a - -
+  }
+}
+`,
+			want: `service S {
+	middleware: ["auth"]
+
+	group /a {
+		// 注释
+		@Get GET /a - -
+	}
+}
+`,
+		},
+		{
+			name: "写在一行中的代码块保持原样",
+			in: `options { packageName: v1 }
+type A { ID int; Name string }
+enum S int { A = 1; B = 2 }
+service S {
+group /g { @B GET /b A A }
+@C GET /c A A
+}
+@Ping GET /ping A A
+`,
+			want: `options { packageName: v1 }
+
+type A { ID int; Name string }
+
+enum S int { A = 1; B = 2 }
+
+service S {
+	group /g { @B GET /b A A }
+	@C GET /c A A
+}
+@Ping GET /ping A A
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatContent(tt.in); got != tt.want {
+				t.Errorf("formatContent() =\n%s\n期望\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "语法错误",
+			in:      "options {\n\tpackageName: v1\n}\ntype A {\n",
+			wantErr: "文件存在语法错误",
+		},
+		{
+			name: "代码块的内容与 { 在同一行",
+			in: `options { packageName: v1
+	outputDir: . }
+`,
+			wantErr: "格式化会改变文件",
+		},
+		{
+			name: "以分号分隔的配置项",
+			in: `options {
+packageName: v1; outputDir: .
+}
+`,
+			want: `options {
+	packageName: v1; outputDir: .
+}
+`,
+		},
+		{
+			name: "以分号分隔的字段",
+			in: `type A {
+ID int; Name string
+}
+`,
+			want: `type A {
+	ID int; Name string
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := format("test.gin", tt.in)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("format() 错误 = %v，期望包含 %q\n%s", err, tt.wantErr, formatContent(tt.in))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("format() 错误 = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("format() =\n%s\n期望\n%s", got, tt.want)
+			}
+		})
+	}
+}
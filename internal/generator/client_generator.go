@@ -0,0 +1,127 @@
+package generator
+
+import (
+	"bytes"
+	_ "embed"
+	"go/format"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/YuukiKazuto/kratosgin/internal/parser"
+)
+
+//go:embed templates/client.tmpl
+var clientTemplate string
+
+const clientFileName = "client.go"
+
+// clientService 生成 HTTP 客户端的一个服务接口
+type clientService struct {
+	Name   string
+	Routes []clientRoute
+}
+
+// clientRoute HTTP 客户端中的一个方法
+type clientRoute struct {
+	Service     string
+	Name        string
+	HTTPMethod  string
+	MethodConst string
+	Pattern     string
+	Request     string // 没有请求类型时为空
+	Response    string // 没有响应类型时为空
+	Description string
+	HasBody     bool // GET 和 HEAD 请求不发送请求体，字段编码为查询参数
+	NoContent   bool // 没有响应类型或状态码为 204 时不解码响应体
+	HasHeader   bool // 请求中有带 header 标签的字段，通过 khttp.Header 作为请求头发送
+}
+
+// httpMethodConsts net/http 中定义的请求方法常量
+var httpMethodConsts = map[string]string{
+	http.MethodGet:     "http.MethodGet",
+	http.MethodHead:    "http.MethodHead",
+	http.MethodPost:    "http.MethodPost",
+	http.MethodPut:     "http.MethodPut",
+	http.MethodPatch:   "http.MethodPatch",
+	http.MethodDelete:  "http.MethodDelete",
+	http.MethodOptions: "http.MethodOptions",
+}
+
+// generateClient 生成通过 HTTP 实现服务接口的 client.go
+// 关闭 client 选项时删除之前生成的文件
+func (g *CodeGenerator) generateClient() error {
+	clientPath := filepath.Join(g.template.Options.OutputDir, clientFileName)
+
+	services := g.clientServices()
+	if !g.template.Options.Client || len(services) == 0 {
+		return removeGeneratedFile(clientPath, generatedHeader, "客户端文件")
+	}
+
+	t, err := template.New("client.tmpl").Parse(clientTemplate)
+	if err != nil {
+		return err
+	}
+	// 使用响应包装时将响应解码到包装的数据字段中
+	var envelope, envelopeData string
+	if typ, ok := g.template.EnvelopeType(); ok {
+		if fields := typ.EnvelopeDataFields(); len(fields) > 0 {
+			envelope, envelopeData = typ.Name, fields[0].Name
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, struct {
+		PackageName  string
+		Services     []clientService
+		Envelope     string
+		EnvelopeData string
+	}{
+		PackageName:  g.template.Options.PackageName,
+		Services:     services,
+		Envelope:     envelope,
+		EnvelopeData: envelopeData,
+	}); err != nil {
+		return err
+	}
+	content, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(clientPath, content, 0644)
+}
+
+// clientServices 按服务接口整理全部路由
+func (g *CodeGenerator) clientServices() []clientService {
+	var services []clientService
+	index := make(map[string]int)
+	for _, service := range g.template.AllServices() {
+		index[service.Name] = len(services)
+		services = append(services, clientService{Name: service.Name})
+	}
+
+	for _, route := range g.template.Routes() {
+		method := strings.ToUpper(route.HTTPMethod)
+		methodConst, ok := httpMethodConsts[method]
+		if !ok {
+			methodConst = `"` + method + `"`
+		}
+		i := index[route.Service]
+		services[i].Routes = append(services[i].Routes, clientRoute{
+			Service:     route.Service,
+			Name:        strings.Title(route.Name),
+			HTTPMethod:  method,
+			MethodConst: methodConst,
+			Pattern:     route.FullPath,
+			Request:     route.Request,
+			Response:    route.Response,
+			Description: route.Description,
+			HasBody:     parser.HasBody(method),
+			NoContent:   !route.HasResponse() || route.StatusCode() == http.StatusNoContent,
+			HasHeader:   route.HasRequest() && len(g.template.RequestBinding(route).Headers) > 0,
+		})
+	}
+	return services
+}
@@ -51,6 +51,11 @@ func (g *CodeGenerator) Generate() error {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
+	// 生成的文件名是固定的，多个 .gin 文件输出到同一个包时会互相覆盖，共享类型也会重复定义
+	if err := checkSource(filepath.Join(outputDir, "types.go"), g.source()); err != nil {
+		return err
+	}
+
 	// 生成类型定义文件
 	if err := g.generateTypes(); err != nil {
 		return fmt.Errorf("failed to generate types: %w", err)
@@ -68,21 +73,13 @@ func (g *CodeGenerator) Generate() error {
 
 	// 检查是否有方法需要 gin context，如果有则生成 context 工具文件
 	hasGinContext := false
-	for _, service := range g.template.Services {
-		for _, method := range service.Methods {
+	for _, service := range g.template.AllServices() {
+		for _, method := range service.AllMethods() {
 			if method.WithGinContext {
 				hasGinContext = true
 				break
 			}
 		}
-		for _, group := range service.RouteGroups {
-			for _, method := range group.Methods {
-				if method.WithGinContext {
-					hasGinContext = true
-					break
-				}
-			}
-		}
 		if hasGinContext {
 			break
 		}
@@ -94,6 +91,31 @@ func (g *CodeGenerator) Generate() error {
 		}
 	}
 
+	// 生成校验错误翻译器的初始化和语言选择函数
+	if err := g.generateTranslator(); err != nil {
+		return fmt.Errorf("生成翻译器文件失败: %w", err)
+	}
+
+	// 生成自定义校验规则，没有声明时清理之前生成的文件
+	if err := g.generateValidators(); err != nil {
+		return fmt.Errorf("生成校验规则失败: %w", err)
+	}
+
+	// 生成响应包装，关闭时清理之前生成的文件
+	if err := g.generateEnvelope(); err != nil {
+		return fmt.Errorf("生成响应包装失败: %w", err)
+	}
+
+	// 生成接口文档，关闭时清理之前生成的文档文件
+	if err := g.generateDocs(); err != nil {
+		return fmt.Errorf("生成接口文档失败: %w", err)
+	}
+
+	// 生成 HTTP 客户端，关闭时清理之前生成的客户端文件
+	if err := g.generateClient(); err != nil {
+		return fmt.Errorf("生成 HTTP 客户端失败: %w", err)
+	}
+
 	// 生成 service 实现
 	if g.template.Options.GenerateService {
 		if err := g.generateServiceImplementations(); err != nil {
@@ -114,7 +136,8 @@ func (g *CodeGenerator) Generate() error {
 // generateTypes 生成类型定义
 func (g *CodeGenerator) generateTypes() error {
 	t, err := template.New("types.tmpl").Funcs(template.FuncMap{
-		"title": strings.Title,
+		"title":  strings.Title,
+		"source": g.source,
 	}).Parse(typesTemplate)
 	if err != nil {
 		return err
@@ -129,6 +152,47 @@ func (g *CodeGenerator) generateTypes() error {
 	return t.Execute(file, g.template)
 }
 
+// source 返回 .gin 文件相对于输出目录的路径，记录在 types.go 的头部注释中
+// 生成时的工作目录为 .gin 文件所在目录
+func (g *CodeGenerator) source() string {
+	if g.template.File == "" {
+		return ""
+	}
+	name := filepath.Base(g.template.File)
+	ginPath, err := filepath.Abs(name)
+	if err != nil {
+		return name
+	}
+	outputDir, err := filepath.Abs(g.template.Options.OutputDir)
+	if err != nil {
+		return name
+	}
+	rel, err := filepath.Rel(outputDir, ginPath)
+	if err != nil {
+		return name
+	}
+	return filepath.ToSlash(rel)
+}
+
+// checkSource 检查已有的 types.go 是否由同一个 .gin 文件生成，没有记录来源的文件不检查
+func checkSource(typesPath, source string) error {
+	content, err := os.ReadFile(typesPath)
+	if err != nil || source == "" {
+		return nil
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.HasPrefix(line, "package ") {
+			break
+		}
+		if prev, ok := strings.CutPrefix(line, "// source: "); ok && prev != source {
+			return fmt.Errorf("%s 由 %s 生成，不能再由 %s 生成: 多个 .gin 文件不能输出到同一个包，"+
+				"请将共享的类型放到单独的 .gin 文件中导入，并为每个 .gin 文件使用不同的 outputDir；重命名了 .gin 文件时请先删除该文件",
+				typesPath, prev, source)
+		}
+	}
+	return nil
+}
+
 // generateServiceInterface 生成服务接口
 func (g *CodeGenerator) generateServiceInterface() error {
 	t, err := template.New("service.tmpl").Funcs(template.FuncMap{
@@ -182,7 +246,11 @@ func (g *CodeGenerator) inferModuleName() string {
 
 // findProjectRoot 从输出目录向上查找项目根目录
 func (g *CodeGenerator) findProjectRoot(startDir string) string {
-	dir := startDir
+	// 使用绝对路径，否则从 "." 出发无法向上查找
+	dir, err := filepath.Abs(startDir)
+	if err != nil {
+		dir = startDir
+	}
 	for {
 		goModPath := filepath.Join(dir, "go.mod")
 		if _, err := os.Stat(goModPath); err == nil {
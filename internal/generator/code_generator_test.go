@@ -0,0 +1,60 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckSource(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string // 为空时不创建 types.go
+		source   string
+		err      string
+	}{
+		{name: "没有 types.go", source: "user.gin"},
+		{
+			name:     "同一个 .gin 文件",
+			existing: "// Code generated by kratosgin. DO NOT EDIT.\n// source: user.gin\n\npackage v1\n",
+			source:   "user.gin",
+		},
+		{
+			name:     "没有记录来源",
+			existing: "// Code generated by kratosgin. DO NOT EDIT.\n\npackage v1\n",
+			source:   "user.gin",
+		},
+		{
+			name:     "package 之后的注释不是来源",
+			existing: "// Code generated by kratosgin. DO NOT EDIT.\n\npackage v1\n\n// source: order.gin\n",
+			source:   "user.gin",
+		},
+		{
+			name:     "其他 .gin 文件",
+			existing: "// Code generated by kratosgin. DO NOT EDIT.\n// source: order.gin\n\npackage v1\n",
+			source:   "user.gin",
+			err:      "由 order.gin 生成，不能再由 user.gin 生成",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			typesPath := filepath.Join(t.TempDir(), "types.go")
+			if tt.existing != "" {
+				if err := os.WriteFile(typesPath, []byte(tt.existing), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			err := checkSource(typesPath, tt.source)
+			if tt.err == "" {
+				if err != nil {
+					t.Fatalf("错误 = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("错误 = %v，期望包含 %q", err, tt.err)
+			}
+		})
+	}
+}
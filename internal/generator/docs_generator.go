@@ -0,0 +1,95 @@
+package generator
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/template"
+
+	"github.com/YuukiKazuto/kratosgin/internal/openapi"
+)
+
+//go:embed templates/docs.tmpl
+var docsTemplate string
+
+//go:embed templates/docs_disabled.tmpl
+var docsDisabledTemplate string
+
+const (
+	docsFileName         = "docs.go"
+	docsDisabledFileName = "docs_disabled.go"
+	specFileName         = "openapi.yaml"
+
+	// generatedHeader 生成文件的首行，只有带有该行的文件才会在关闭对应选项时被删除
+	generatedHeader     = "// Code generated by kratosgin. DO NOT EDIT."
+	generatedSpecHeader = "# Code generated by kratosgin. DO NOT EDIT."
+)
+
+// generateDocs 生成内嵌 OpenAPI 文档和 Swagger UI 的 docs.go，以及没有 docs 编译标签时使用的空实现 docs_disabled.go
+// 同一份生成代码使用 -tags docs 编译时提供接口文档，否则 RegisterDocs 不做任何事
+// 关闭 docs 选项时删除之前生成的文件
+func (g *CodeGenerator) generateDocs() error {
+	outputDir := g.template.Options.OutputDir
+	docsPath := filepath.Join(outputDir, docsFileName)
+	disabledPath := filepath.Join(outputDir, docsDisabledFileName)
+	specPath := filepath.Join(outputDir, specFileName)
+
+	if !g.template.Options.Docs {
+		for _, path := range []string{docsPath, disabledPath} {
+			if err := removeGeneratedFile(path, generatedHeader, "文档文件"); err != nil {
+				return err
+			}
+		}
+		return removeGeneratedFile(specPath, generatedSpecHeader, "文档文件")
+	}
+
+	doc := openapi.Build(g.template)
+	spec, err := doc.YAML()
+	if err != nil {
+		return fmt.Errorf("编码 OpenAPI 文档失败: %w", err)
+	}
+	spec = append([]byte(generatedSpecHeader+"\n"), spec...)
+	if err := os.WriteFile(specPath, spec, 0644); err != nil {
+		return err
+	}
+
+	data := struct {
+		PackageName string
+		Title       string // 转义后的 Go 字符串字面量，标题中可以包含反引号
+	}{
+		PackageName: g.template.Options.PackageName,
+		Title:       strconv.Quote(html.EscapeString(doc.Info.Title)),
+	}
+	if err := writeDocsFile(docsPath, "docs.tmpl", docsTemplate, data); err != nil {
+		return err
+	}
+	return writeDocsFile(disabledPath, "docs_disabled.tmpl", docsDisabledTemplate, data)
+}
+
+func writeDocsFile(path, name, text string, data interface{}) error {
+	t, err := template.New(name).Parse(text)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// removeGeneratedFile 删除由 kratosgin 生成的文件，没有生成标记的文件保持不变
+func removeGeneratedFile(path, header, kind string) error {
+	if !hasScaffoldMarker(path, header) {
+		return nil
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("删除%s失败: %w", kind, err)
+	}
+	fmt.Printf("已删除%s: %s\n", kind, path)
+	return nil
+}
@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	ginparser "github.com/YuukiKazuto/kratosgin/internal/parser"
+)
+
+// newTestGenerator 解析 options 块加 source 的源码，返回输出到临时目录的生成器
+func newTestGenerator(t *testing.T, options, source string) *CodeGenerator {
+	t.Helper()
+	file, diags := ginparser.ParseFile("test.gin", "options {\n\tpackageName: v1\n"+options+"}\n"+source)
+	if diags.HasErrors() {
+		t.Fatalf("解析失败:\n%v", diags)
+	}
+	template := ginparser.BuildTemplate(file)
+	template.Options.OutputDir = t.TempDir()
+	return NewCodeGenerator(template)
+}
+
+// existingFiles 返回目录中存在的文件名
+func existingFiles(t *testing.T, dir string, names ...string) []string {
+	t.Helper()
+	var existing []string
+	for _, name := range names {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			existing = append(existing, name)
+		}
+	}
+	return existing
+}
+
+func TestGenerateDocs(t *testing.T) {
+	const source = `info {
+	title: "Item API"
+}
+@Ping GET /ping - -
+`
+	files := []string{docsFileName, docsDisabledFileName, specFileName}
+
+	tests := []struct {
+		name    string
+		handled string // 关闭选项前替换为手写内容的文件
+		want    []string
+	}{
+		{name: "关闭选项时删除生成的文件"},
+		{name: "保留没有生成标记的文件", handled: docsFileName, want: []string{docsFileName}},
+		{name: "保留没有生成标记的 OpenAPI 文档", handled: specFileName, want: []string{specFileName}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGenerator(t, "\tdocs: true\n", source)
+			dir := g.template.Options.OutputDir
+			if err := g.generateDocs(); err != nil {
+				t.Fatal(err)
+			}
+			if got := existingFiles(t, dir, files...); len(got) != len(files) {
+				t.Fatalf("生成的文件 = %q，期望 %q", got, files)
+			}
+			spec, err := os.ReadFile(filepath.Join(dir, specFileName))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(string(spec), generatedSpecHeader+"\n") || !strings.Contains(string(spec), "title: Item API") {
+				t.Errorf("OpenAPI 文档 =\n%s", spec)
+			}
+
+			if tt.handled != "" {
+				if err := os.WriteFile(filepath.Join(dir, tt.handled), []byte("// 手写的文件\n"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			g.template.Options.Docs = false
+			if err := g.generateDocs(); err != nil {
+				t.Fatal(err)
+			}
+			if got := existingFiles(t, dir, files...); strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("关闭 docs 后剩余的文件 = %q，期望 %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package generator
+
+import (
+	"bytes"
+	_ "embed"
+	"go/format"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/YuukiKazuto/kratosgin/internal/parser"
+)
+
+//go:embed templates/envelope.tmpl
+var envelopeTemplate string
+
+const envelopeFileName = "envelope.go"
+
+// generateEnvelope 生成处理器使用的响应包装函数
+// 关闭 envelope 选项时删除之前生成的文件
+func (g *CodeGenerator) generateEnvelope() error {
+	envelopePath := filepath.Join(g.template.Options.OutputDir, envelopeFileName)
+
+	typ, ok := g.template.EnvelopeType()
+	if !ok {
+		return removeGeneratedFile(envelopePath, generatedHeader, "响应包装文件")
+	}
+
+	t, err := template.New("envelope.tmpl").Parse(envelopeTemplate)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, struct {
+		PackageName string
+		Standard    bool
+		Type        parser.Type
+		Func        string
+	}{
+		PackageName: g.template.Options.PackageName,
+		Standard:    g.template.Options.StandardEnvelope(),
+		Type:        typ,
+		Func:        g.template.Options.EnvelopeFuncName(),
+	}); err != nil {
+		return err
+	}
+	content, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(envelopePath, content, 0644)
+}
@@ -0,0 +1,60 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateEnvelope(t *testing.T) {
+	const source = `type (
+	Result {
+		Code int ` + "`json:\"code\"`" + `
+		Data interface{} ` + "`json:\"data\"`" + `
+	}
+)
+@Ping GET /ping - -
+`
+	tests := []struct {
+		name     string
+		envelope string
+		handled  bool // 关闭选项前是否替换为手写内容
+		want     []string
+	}{
+		{name: "标准响应包装", envelope: "standard", want: []string{"type Envelope struct", "func newEnvelope("}},
+		{name: "自定义响应包装", envelope: "Result", want: []string{"func newEnvelope(", "NewResult("}},
+		{name: "保留没有生成标记的文件", envelope: "standard", handled: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGenerator(t, "\tenvelope: "+tt.envelope+"\n", source)
+			path := filepath.Join(g.template.Options.OutputDir, envelopeFileName)
+			if err := g.generateEnvelope(); err != nil {
+				t.Fatal(err)
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(content), want) {
+					t.Errorf("envelope.go 缺少 %q:\n%s", want, content)
+				}
+			}
+
+			if tt.handled {
+				if err := os.WriteFile(path, []byte("package v1\n"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			g.template.Options.Envelope = ""
+			if err := g.generateEnvelope(); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := os.Stat(path); os.IsNotExist(err) != !tt.handled {
+				t.Errorf("关闭 envelope 后 envelope.go 存在 = %v，期望 %v", !os.IsNotExist(err), tt.handled)
+			}
+		})
+	}
+}
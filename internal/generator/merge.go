@@ -0,0 +1,322 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// mergeResult 记录合并已有实现文件时的改动
+type mergeResult struct {
+	Added   []string // 新增的方法
+	Updated []string // 更新了签名的方法
+	Removed []string // 已不在 .gin 文件中的方法，只报告不删除
+}
+
+func (r mergeResult) changed() bool {
+	return len(r.Added) > 0 || len(r.Updated) > 0
+}
+
+// textEdit 表示对源文件 [start, end) 区间的一次替换
+type textEdit struct {
+	start, end int
+	text       string
+}
+
+// existingImportName 返回已有文件中导入 importPath 时使用的包名，没有导入时返回空字符串
+func existingImportName(filename, importPath string) string {
+	file, err := parser.ParseFile(token.NewFileSet(), filename, nil, parser.ImportsOnly)
+	if err != nil {
+		return ""
+	}
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil || path != importPath {
+			continue
+		}
+		if spec.Name != nil {
+			return spec.Name.Name
+		}
+		return path[strings.LastIndex(path, "/")+1:]
+	}
+	return ""
+}
+
+// mergeImplementation 将模板生成的实现合并到已有文件中
+// 已有方法的方法体保持不变，只更新与生成结果不一致的参数和返回值类型；
+// 缺少的方法和导入从生成结果中追加；生成结果中没有的导出方法只在结果中报告
+func mergeImplementation(filename, receiver string, generated []byte) (mergeResult, error) {
+	var result mergeResult
+
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return result, err
+	}
+
+	fset := token.NewFileSet()
+	existing, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return result, fmt.Errorf("解析已有文件失败: %w", err)
+	}
+	genFset := token.NewFileSet()
+	genFile, err := parser.ParseFile(genFset, "", generated, parser.ParseComments)
+	if err != nil {
+		return result, fmt.Errorf("解析生成结果失败: %w", err)
+	}
+
+	if !declaresType(existing, receiver) {
+		return result, fmt.Errorf("未找到实现结构体 %s", receiver)
+	}
+
+	offset := func(pos token.Pos) int { return fset.Position(pos).Offset }
+	genOffset := func(pos token.Pos) int { return genFset.Position(pos).Offset }
+
+	existingMethods := receiverMethods(existing, receiver)
+	generatedMethods := receiverMethods(genFile, receiver)
+
+	var edits []textEdit
+	var appended strings.Builder
+	var inserted []ast.Node // 插入到已有文件中的生成代码，用于确定需要补充的导入
+	expected := make(map[string]bool)
+	for _, genDecl := range generatedMethods {
+		name := genDecl.Name.Name
+		expected[name] = true
+
+		decl, ok := findMethod(existingMethods, name)
+		if !ok {
+			start := genOffset(genDecl.Pos())
+			if genDecl.Doc != nil {
+				start = genOffset(genDecl.Doc.Pos())
+			}
+			appended.WriteString("\n")
+			appended.Write(generated[start:genOffset(genDecl.End())])
+			appended.WriteString("\n")
+			inserted = append(inserted, genDecl)
+			result.Added = append(result.Added, name)
+			continue
+		}
+
+		if signatureEdits := signatureEdits(decl.Type, genDecl.Type, offset, func(node ast.Node) string {
+			inserted = append(inserted, node)
+			return string(generated[genOffset(node.Pos()):genOffset(node.End())])
+		}); len(signatureEdits) > 0 {
+			edits = append(edits, signatureEdits...)
+			result.Updated = append(result.Updated, name)
+		}
+	}
+
+	for _, decl := range existingMethods {
+		if decl.Name.IsExported() && !expected[decl.Name.Name] {
+			result.Removed = append(result.Removed, decl.Name.Name)
+		}
+	}
+
+	if !result.changed() {
+		return result, nil
+	}
+
+	edits = append(edits, importEdits(existing, genFile, inserted, src, offset)...)
+
+	// 从后往前替换，保证前面的偏移量不变
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].start > edits[j].start })
+	out := append([]byte(nil), src...)
+	for _, edit := range edits {
+		out = append(out[:edit.start], append([]byte(edit.text), out[edit.end:]...)...)
+	}
+	out = append(bytes.TrimRight(out, "\n"), '\n')
+	out = append(out, appended.String()...)
+
+	formatted, err := format.Source(out)
+	if err != nil {
+		return result, fmt.Errorf("格式化合并结果失败: %w", err)
+	}
+	return result, os.WriteFile(filename, formatted, 0644)
+}
+
+// declaresType 判断文件中是否声明了指定类型
+func declaresType(file *ast.File, name string) bool {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			if spec.(*ast.TypeSpec).Name.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// receiverMethods 返回文件中接收者为 receiver 或 *receiver 的方法
+func receiverMethods(file *ast.File, receiver string) []*ast.FuncDecl {
+	var methods []*ast.FuncDecl
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 {
+			continue
+		}
+		typ := fn.Recv.List[0].Type
+		if star, ok := typ.(*ast.StarExpr); ok {
+			typ = star.X
+		}
+		if ident, ok := typ.(*ast.Ident); ok && ident.Name == receiver {
+			methods = append(methods, fn)
+		}
+	}
+	return methods
+}
+
+func findMethod(methods []*ast.FuncDecl, name string) (*ast.FuncDecl, bool) {
+	for _, method := range methods {
+		if method.Name.Name == name {
+			return method, true
+		}
+	}
+	return nil, false
+}
+
+// signatureEdits 比较已有方法与生成方法的参数和返回值类型
+// 字段结构一致时只替换不同的类型，保留已有的参数名；否则替换整个签名
+func signatureEdits(existing, generated *ast.FuncType, offset func(token.Pos) int, genText func(ast.Node) string) []textEdit {
+	if sameFieldLayout(existing.Params, generated.Params) && sameFieldLayout(existing.Results, generated.Results) {
+		var edits []textEdit
+		for _, pair := range [][2]*ast.FieldList{{existing.Params, generated.Params}, {existing.Results, generated.Results}} {
+			if pair[0] == nil {
+				continue
+			}
+			for i, field := range pair[0].List {
+				genField := pair[1].List[i]
+				if types.ExprString(field.Type) != types.ExprString(genField.Type) {
+					edits = append(edits, textEdit{offset(field.Type.Pos()), offset(field.Type.End()), genText(genField.Type)})
+				}
+			}
+		}
+		return edits
+	}
+	// 参数名的写法不同（如省略参数名）但类型一致时保持不变
+	if reflect.DeepEqual(fieldTypes(existing.Params), fieldTypes(generated.Params)) &&
+		reflect.DeepEqual(fieldTypes(existing.Results), fieldTypes(generated.Results)) {
+		return nil
+	}
+
+	return []textEdit{{
+		start: offset(existing.Params.Pos()),
+		end:   offset(existing.End()),
+		text:  genText(&ast.FuncType{Func: generated.Params.Pos(), Params: generated.Params, Results: generated.Results}),
+	}}
+}
+
+// fieldTypes 按参数逐个列出字段列表中的类型，a, b int 展开为两个 int
+func fieldTypes(list *ast.FieldList) []string {
+	var result []string
+	if list == nil {
+		return result
+	}
+	for _, field := range list.List {
+		typ := types.ExprString(field.Type)
+		for i := 0; i < len(field.Names) || i == 0; i++ {
+			result = append(result, typ)
+		}
+	}
+	return result
+}
+
+// sameFieldLayout 判断两个字段列表的字段数和每个字段的名称数是否一致
+func sameFieldLayout(a, b *ast.FieldList) bool {
+	if a.NumFields() != b.NumFields() || (a == nil) != (b == nil) {
+		return false
+	}
+	if a == nil {
+		return true
+	}
+	if len(a.List) != len(b.List) {
+		return false
+	}
+	for i := range a.List {
+		if len(a.List[i].Names) != len(b.List[i].Names) {
+			return false
+		}
+	}
+	return true
+}
+
+// importEdits 为已有文件补充插入的代码中用到但尚未导入的包，生成结果中的其他导入不会添加
+func importEdits(existing, generated *ast.File, inserted []ast.Node, src []byte, offset func(token.Pos) int) []textEdit {
+	imported := make(map[string]bool)
+	for _, spec := range existing.Imports {
+		imported[spec.Path.Value] = true
+	}
+
+	// 插入的代码中以 pkg.Name 形式引用的包名，包名在语法树中没有对应的声明
+	used := make(map[string]bool)
+	for _, node := range inserted {
+		ast.Inspect(node, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok {
+				if ident, ok := sel.X.(*ast.Ident); ok && ident.Obj == nil {
+					used[ident.Name] = true
+				}
+			}
+			return true
+		})
+	}
+
+	var missing []string
+	for _, spec := range generated.Imports {
+		if imported[spec.Path.Value] || !used[importName(spec)] {
+			continue
+		}
+		line := spec.Path.Value
+		if spec.Name != nil {
+			line = spec.Name.Name + " " + line
+		}
+		missing = append(missing, line)
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	// 优先追加到已有的 import ( ... ) 中
+	for _, decl := range existing.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if ok && gen.Tok == token.IMPORT && gen.Lparen.IsValid() {
+			pos := offset(gen.Rparen)
+			text := "\t" + strings.Join(missing, "\n\t") + "\n"
+			if !bytes.HasSuffix(bytes.TrimRight(src[:pos], " \t"), []byte("\n")) {
+				text = "\n" + text
+			}
+			return []textEdit{{pos, pos, text}}
+		}
+	}
+
+	pos := offset(existing.Name.End())
+	text := "\n\nimport (\n\t" + strings.Join(missing, "\n\t") + "\n)"
+	return []textEdit{{pos, pos, text}}
+}
+
+// importName 返回导入的包在代码中使用的名称，没有别名时取路径的最后一段，并去掉 /v2 这样的主版本号
+func importName(spec *ast.ImportSpec) string {
+	if spec.Name != nil {
+		return spec.Name.Name
+	}
+	path, err := strconv.Unquote(spec.Path.Value)
+	if err != nil {
+		return ""
+	}
+	parts := strings.Split(path, "/")
+	name := parts[len(parts)-1]
+	if len(parts) > 1 && len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = parts[len(parts)-2]
+	}
+	return name
+}
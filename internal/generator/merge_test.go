@@ -0,0 +1,310 @@
+package generator
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// generatedService 模板生成的实现，CreateUser 的请求类型已改为 CreateUserReq，GetUser 增加了 gin.Context 参数，并新增了 DeleteUser
+// log 只在结构体中使用，合并时不会导入
+const generatedService = `package service
+
+import (
+	"context"
+
+	v1 "example/api/user/v1"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+type UserService struct {
+	log *log.Helper
+}
+
+// CreateUser 创建用户
+func (s *UserService) CreateUser(ctx context.Context, req *v1.CreateUserReq) (*v1.UserResp, error) {
+	return nil, nil
+}
+
+// GetUser 获取用户
+func (s *UserService) GetUser(ctx context.Context, ginCtx *gin.Context, req *v1.GetUserReq) (*v1.UserResp, error) {
+	return nil, nil
+}
+
+// DeleteUser 删除用户
+func (s *UserService) DeleteUser(ctx context.Context, req *v1.DeleteUserReq) (*v1.Empty, error) {
+	return nil, nil
+}
+`
+
+func TestMergeImplementation(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		want     string // 为空时要求文件不变
+		result   mergeResult
+		err      string
+	}{
+		{
+			name: "保留用户代码并合并签名和新方法",
+			existing: `package service
+
+import (
+	"context"
+
+	v1 "example/api/user/v1"
+)
+
+// UserService 用户服务
+type UserService struct {
+	repo map[int64]string
+}
+
+// CreateUser 创建用户，已实现
+func (s *UserService) CreateUser(c context.Context, in *v1.OldCreateReq) (*v1.UserResp, error) {
+	s.repo[1] = in.Name // 用户代码
+	return &v1.UserResp{ID: 1}, nil
+}
+
+func (s *UserService) GetUser(ctx context.Context, req *v1.GetUserReq) (*v1.UserResp, error) {
+	return s.load(req.ID)
+}
+
+// load 用户添加的辅助方法
+func (s *UserService) load(id int64) (*v1.UserResp, error) {
+	return &v1.UserResp{ID: id}, nil
+}
+
+// Legacy 已从 .gin 文件中删除的方法
+func (s *UserService) Legacy() {}
+`,
+			want: `package service
+
+import (
+	"context"
+
+	v1 "example/api/user/v1"
+	"github.com/gin-gonic/gin"
+)
+
+// UserService 用户服务
+type UserService struct {
+	repo map[int64]string
+}
+
+// CreateUser 创建用户，已实现
+func (s *UserService) CreateUser(c context.Context, in *v1.CreateUserReq) (*v1.UserResp, error) {
+	s.repo[1] = in.Name // 用户代码
+	return &v1.UserResp{ID: 1}, nil
+}
+
+func (s *UserService) GetUser(ctx context.Context, ginCtx *gin.Context, req *v1.GetUserReq) (*v1.UserResp, error) {
+	return s.load(req.ID)
+}
+
+// load 用户添加的辅助方法
+func (s *UserService) load(id int64) (*v1.UserResp, error) {
+	return &v1.UserResp{ID: id}, nil
+}
+
+// Legacy 已从 .gin 文件中删除的方法
+func (s *UserService) Legacy() {}
+
+// DeleteUser 删除用户
+func (s *UserService) DeleteUser(ctx context.Context, req *v1.DeleteUserReq) (*v1.Empty, error) {
+	return nil, nil
+}
+`,
+			result: mergeResult{
+				Added:   []string{"DeleteUser"},
+				Updated: []string{"CreateUser", "GetUser"},
+				Removed: []string{"Legacy"},
+			},
+		},
+		{
+			name: "单行导入改为追加导入块",
+			existing: `package service
+
+import "context"
+
+import v1 "example/api/user/v1"
+
+type UserService struct{}
+
+func (s UserService) CreateUser(ctx context.Context, req *v1.CreateUserReq) (*v1.UserResp, error) {
+	return nil, nil
+}
+
+func (s UserService) GetUser(ctx context.Context, req *v1.GetUserReq) (*v1.UserResp, error) {
+	return nil, nil
+}
+`,
+			want: `package service
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+import "context"
+
+import v1 "example/api/user/v1"
+
+type UserService struct{}
+
+func (s UserService) CreateUser(ctx context.Context, req *v1.CreateUserReq) (*v1.UserResp, error) {
+	return nil, nil
+}
+
+func (s UserService) GetUser(ctx context.Context, ginCtx *gin.Context, req *v1.GetUserReq) (*v1.UserResp, error) {
+	return nil, nil
+}
+
+// DeleteUser 删除用户
+func (s *UserService) DeleteUser(ctx context.Context, req *v1.DeleteUserReq) (*v1.Empty, error) {
+	return nil, nil
+}
+`,
+			result: mergeResult{Added: []string{"DeleteUser"}, Updated: []string{"GetUser"}},
+		},
+		{
+			name: "只导入新增方法中用到的包",
+			existing: `package service
+
+import (
+	"context"
+
+	v1 "example/api/user/v1"
+	"github.com/gin-gonic/gin"
+)
+
+type UserService struct{}
+
+func (s *UserService) CreateUser(ctx context.Context, req *v1.CreateUserReq) (*v1.UserResp, error) {
+	return nil, nil
+}
+
+func (s *UserService) GetUser(ctx context.Context, ginCtx *gin.Context, req *v1.GetUserReq) (*v1.UserResp, error) {
+	return nil, nil
+}
+`,
+			want: `package service
+
+import (
+	"context"
+
+	v1 "example/api/user/v1"
+	"github.com/gin-gonic/gin"
+)
+
+type UserService struct{}
+
+func (s *UserService) CreateUser(ctx context.Context, req *v1.CreateUserReq) (*v1.UserResp, error) {
+	return nil, nil
+}
+
+func (s *UserService) GetUser(ctx context.Context, ginCtx *gin.Context, req *v1.GetUserReq) (*v1.UserResp, error) {
+	return nil, nil
+}
+
+// DeleteUser 删除用户
+func (s *UserService) DeleteUser(ctx context.Context, req *v1.DeleteUserReq) (*v1.Empty, error) {
+	return nil, nil
+}
+`,
+			result: mergeResult{Added: []string{"DeleteUser"}},
+		},
+		{
+			name: "签名一致时不改写文件，省略参数名也视为一致",
+			existing: `package service
+
+import (
+	"context"
+	v1 "example/api/user/v1"
+	"github.com/gin-gonic/gin"
+)
+
+type UserService struct{}
+
+func (s *UserService) CreateUser(ctx context.Context, req *v1.CreateUserReq) (*v1.UserResp, error) { return nil, nil }
+
+func (s *UserService) GetUser(ctx context.Context, g *gin.Context, r *v1.GetUserReq) (*v1.UserResp, error) {
+	return nil, nil
+}
+
+func (s *UserService) DeleteUser(context.Context, *v1.DeleteUserReq) (*v1.Empty, error) {
+	return nil, nil
+}
+`,
+		},
+		{
+			name: "缺少实现结构体",
+			existing: `package service
+
+type OtherService struct{}
+`,
+			err: "未找到实现结构体 UserService",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "user.go")
+			if err := os.WriteFile(filename, []byte(tt.existing), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := mergeImplementation(filename, "UserService", []byte(generatedService))
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("错误 = %v，期望包含 %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(result, tt.result) {
+				t.Errorf("合并结果 = %+v，期望 %+v", result, tt.result)
+			}
+
+			got, err := os.ReadFile(filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := tt.want
+			if want == "" {
+				want = tt.existing
+			}
+			if string(got) != want {
+				t.Errorf("合并后的文件 =\n%s\n期望\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestImportName(t *testing.T) {
+	tests := []struct {
+		spec string
+		want string
+	}{
+		{`"context"`, "context"},
+		{`"github.com/gin-gonic/gin"`, "gin"},
+		{`"github.com/go-kratos/kratos/v2"`, "kratos"},
+		{`"github.com/go-kratos/kratos/v2/log"`, "log"},
+		{`khttp "github.com/go-kratos/kratos/v2/transport/http"`, "khttp"},
+	}
+	for _, tt := range tests {
+		file, err := parser.ParseFile(token.NewFileSet(), "", "package p\nimport "+tt.spec, parser.ImportsOnly)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := importName(file.Imports[0]); got != tt.want {
+			t.Errorf("importName(%s) = %q，期望 %q", tt.spec, got, tt.want)
+		}
+	}
+}
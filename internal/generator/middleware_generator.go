@@ -1,8 +1,11 @@
 package generator
 
 import (
+	"bufio"
+	"bytes"
 	_ "embed"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -41,114 +44,162 @@ func (g *CodeGenerator) generateMiddlewareImplementations() error {
 		return fmt.Errorf("创建中间件目录失败: %w", err)
 	}
 
-	// 收集所有中间件名称
-	middlewareNames := make(map[string]bool)
-	for _, service := range g.template.Services {
-		// 收集服务级中间件
-		for _, middleware := range service.Middleware {
-			cleanMiddleware := strings.Trim(middleware, `"'`)
-			if cleanMiddleware != "" {
-				middlewareNames[cleanMiddleware] = true
-			}
+	// 每个服务、顶级分组和独立路由各自生成一个中间件实现
+	// 没有中间件的处理器不生成文件，已有的文件按孤立文件处理，不会直接删除
+	expected := make(map[string]bool)
+	for _, owner := range middlewareOwners(g.template) {
+		if !owner.hasMiddleware() {
+			continue
 		}
-		// 收集路由组中间件
-		for _, group := range service.RouteGroups {
-			for _, middleware := range group.Middleware {
-				cleanMiddleware := strings.Trim(middleware, `"'`)
-				if cleanMiddleware != "" {
-					middlewareNames[cleanMiddleware] = true
-				}
-			}
+		expected[middlewareFileName(owner)] = true
+
+		if err := g.generateMiddlewareFile(absoluteMiddlewareDir, owner); err != nil {
+			return fmt.Errorf("生成 %s 中间件文件失败: %w", owner.Name, err)
 		}
 	}
 
-	// 如果没有中间件定义，删除已存在的中间件文件
-	if len(middlewareNames) == 0 {
-		if err := g.deleteExistingMiddlewareFiles(absoluteMiddlewareDir); err != nil {
-			return fmt.Errorf("删除中间件文件失败: %w", err)
+	managed, unmanaged, err := g.findOrphanedMiddlewareFiles(absoluteMiddlewareDir, expected)
+	if err != nil {
+		return fmt.Errorf("查找孤立的中间件文件失败: %w", err)
+	}
+	for _, file := range unmanaged {
+		fmt.Printf("警告: 中间件文件不再使用，但不是由 kratosgin 生成，请手动处理: %s\n", file)
+	}
+	if len(managed) == 0 {
+		return nil
+	}
+	if !g.template.Options.PruneMiddleware {
+		fmt.Println("发现孤立的中间件文件，可以使用 --prune 删除:")
+		for _, file := range managed {
+			fmt.Printf("  %s\n", file)
 		}
 		return nil
 	}
+	return pruneFiles(managed, os.Stdin)
+}
 
-	// 生成中间件实现
-	if err := g.generateMiddlewareFile(absoluteMiddlewareDir, middlewareNames); err != nil {
-		return fmt.Errorf("生成中间件文件失败: %w", err)
-	}
+// middlewareFileName 返回中间件实现的文件名
+func middlewareFileName(owner middlewareOwner) string {
+	return strings.ToLower(owner.Name) + ".go"
+}
 
-	return nil
+// scaffoldMarker 返回中间件实现文件的首行标记
+// 带有当前 API 包标记的文件由 kratosgin 管理，不再使用时可以通过 --prune 删除
+func scaffoldMarker(apiImportPath string) string {
+	return "// Code scaffolded by kratosgin for " + apiImportPath + "."
 }
 
-// deleteExistingMiddlewareFiles 删除已存在的中间件文件
-func (g *CodeGenerator) deleteExistingMiddlewareFiles(middlewareDir string) error {
-	// 获取服务名称用于确定要删除的文件名
-	serviceName := "User" // 默认值
-	if len(g.template.Services) > 0 {
-		serviceName = g.template.Services[0].Name
-		// 移除 Service 后缀
-		serviceName = strings.TrimSuffix(serviceName, "Service")
+// hasScaffoldMarker 判断文件在 package 子句之前是否带有指定的标记
+func hasScaffoldMarker(filename, marker string) bool {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return false
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == marker {
+			return true
+		}
+		if strings.HasPrefix(line, "package ") {
+			break
+		}
 	}
+	return false
+}
 
-	filename := strings.ToLower(serviceName) + ".go"
-	filepath := filepath.Join(middlewareDir, filename)
+// findOrphanedMiddlewareFiles 查找当前模板不再使用的中间件文件
+// managed 为带有当前 API 包标记的文件，unmanaged 为与没有中间件的处理器同名但没有标记的文件
+func (g *CodeGenerator) findOrphanedMiddlewareFiles(middlewareDir string, expected map[string]bool) (managed, unmanaged []string, err error) {
+	marker := scaffoldMarker(g.apiImportPath())
 
-	// 检查文件是否存在，如果存在则删除
-	if _, err := os.Stat(filepath); err == nil {
-		if err := os.Remove(filepath); err != nil {
-			return fmt.Errorf("删除中间件文件失败: %w", err)
+	entries, err := os.ReadDir(middlewareDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || expected[name] {
+			continue
+		}
+		path := filepath.Join(middlewareDir, name)
+		if hasScaffoldMarker(path, marker) {
+			managed = append(managed, path)
 		}
-		fmt.Printf("已删除中间件文件: %s\n", filepath)
 	}
 
-	return nil
+	for _, owner := range middlewareOwners(g.template) {
+		name := middlewareFileName(owner)
+		if owner.hasMiddleware() || expected[name] {
+			continue
+		}
+		path := filepath.Join(middlewareDir, name)
+		if _, err := os.Stat(path); err == nil && !hasScaffoldMarker(path, marker) {
+			unmanaged = append(unmanaged, path)
+		}
+	}
+	return managed, unmanaged, nil
 }
 
-// generateMiddlewareFile 生成中间件文件
-func (g *CodeGenerator) generateMiddlewareFile(outputDir string, middlewareNames map[string]bool) error {
-	// 获取服务名称用于生成中间件结构体名称
-	serviceName := "User" // 默认值
-	if len(g.template.Services) > 0 {
-		serviceName = g.template.Services[0].Name
-		// 移除 Service 后缀
-		serviceName = strings.TrimSuffix(serviceName, "Service")
-
+// pruneFiles 列出要删除的文件，确认后删除
+func pruneFiles(files []string, in io.Reader) error {
+	fmt.Println("以下孤立的中间件文件将被删除:")
+	for _, file := range files {
+		fmt.Printf("  %s\n", file)
 	}
+	fmt.Print("确认删除? [y/N]: ")
 
-	filename := strings.ToLower(serviceName) + ".go"
-	filepath := filepath.Join(outputDir, filename)
-
-	// 检查文件是否已存在
-	if _, err := os.Stat(filepath); err == nil {
-		fmt.Printf("中间件文件已存在，跳过生成: %s\n", filepath)
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	if answer != "y" && answer != "yes" {
+		fmt.Println("已取消删除")
 		return nil
 	}
 
+	for _, file := range files {
+		if err := os.Remove(file); err != nil {
+			return fmt.Errorf("删除中间件文件失败: %w", err)
+		}
+		fmt.Printf("已删除中间件文件: %s\n", file)
+	}
+	return nil
+}
+
+// generateMiddlewareFile 生成中间件文件
+func (g *CodeGenerator) generateMiddlewareFile(outputDir string, owner middlewareOwner) error {
+	filepath := filepath.Join(outputDir, middlewareFileName(owner))
+
 	// 准备模板数据
 	moduleName := g.inferModuleName()
 	apiPath, packageName := g.inferAPIPathAndPackage()
 	packageAlias := g.generatePackageAlias(apiPath, packageName)
 
-	var middlewareNamesList []string
-	for middlewareName := range middlewareNames {
-		cleanName := strings.Trim(middlewareName, `"'`)
-		if cleanName != "" {
-			middlewareNamesList = append(middlewareNamesList, cleanName)
+	// 已有文件沿用其中 API 包的导入名
+	_, statErr := os.Stat(filepath)
+	exists := statErr == nil
+	if exists {
+		if name := existingImportName(filepath, g.apiImportPath()); name != "" {
+			packageAlias = name
 		}
 	}
 
 	templateData := struct {
+		Marker          string
 		ServiceName     string
+		InterfaceName   string
 		ModuleName      string
 		APIPath         string
 		PackageName     string
 		PackageAlias    string
 		MiddlewareNames []string
 	}{
-		ServiceName:     serviceName,
+		Marker:          scaffoldMarker(g.apiImportPath()),
+		ServiceName:     owner.Name,
+		InterfaceName:   owner.Interface,
 		ModuleName:      moduleName,
 		APIPath:         apiPath,
 		PackageName:     packageName,
 		PackageAlias:    packageAlias,
-		MiddlewareNames: middlewareNamesList,
+		MiddlewareNames: owner.Names,
 	}
 
 	// 使用模板生成文件
@@ -159,11 +210,20 @@ func (g *CodeGenerator) generateMiddlewareFile(outputDir string, middlewareNames
 		return err
 	}
 
-	file, err := os.Create(filepath)
-	if err != nil {
+	var content bytes.Buffer
+	if err := t.Execute(&content, templateData); err != nil {
 		return err
 	}
-	defer file.Close()
 
-	return t.Execute(file, templateData)
+	// 文件已存在时只追加缺少的中间件方法，保留已有的实现
+	if exists {
+		result, err := mergeImplementation(filepath, owner.Name+"Middleware", content.Bytes())
+		if err != nil {
+			return fmt.Errorf("合并 %s 失败: %w", filepath, err)
+		}
+		printMergeResult("中间件文件", filepath, result)
+		return nil
+	}
+
+	return os.WriteFile(filepath, content.Bytes(), 0644)
 }
@@ -0,0 +1,69 @@
+package generator
+
+import "github.com/YuukiKazuto/kratosgin/internal/parser"
+
+// FixedNames 返回生成器为模板固定输出的包级标识符，不包括由类型、枚举、服务和分组名得到的标识符
+// 语义检查据此报告与生成代码重名的声明；修改模板中的包级声明时需要同步更新，TestFixedNames 会检查两者是否一致
+func FixedNames(template *parser.GinTemplate) []string {
+	names := []string{"translateValidationError", "ValidationReason", "validationFieldKey", "tagName", "bindURI", "bindQuery", "bindHeader",
+		"ErrorEncoder", "BindErrorEncoder", "ResponseEncoder", "HandlerOption", "handlerOptions", "newHandlerOptions",
+		"WithErrorEncoder", "WithBindErrorEncoder", "WithResponseEncoder", "WithUniversalTranslator", "WithLocaleQuery",
+		"NewUniversalTranslator", "DefaultLocaleQuery", "requestLocales", "appendLocale",
+		"DefaultErrorEncoder", "DefaultBindErrorEncoder", "DefaultResponseEncoder"}
+	if len(template.StandaloneRoutes) > 0 {
+		names = append(names, "StandaloneHandler", parser.StandaloneServiceName)
+	}
+
+	hasGinContext := false
+	visit := func(method parser.Method) {
+		if method.WithGinContext {
+			hasGinContext = true
+		}
+	}
+	for _, service := range template.Services {
+		for _, method := range service.AllMethods() {
+			visit(method)
+		}
+	}
+	for _, group := range template.RouteGroups {
+		for _, method := range group.AllMethods() {
+			visit(method)
+		}
+	}
+	standaloneMiddleware := false
+	for _, route := range template.StandaloneRoutes {
+		visit(route.Method)
+		if len(route.Middleware) > 0 {
+			standaloneMiddleware = true
+		}
+	}
+
+	if standaloneMiddleware {
+		names = append(names, "StandaloneMiddleware")
+	}
+	if hasGinContext {
+		names = append(names, "SaveToContext", "FromContext", "GinContextKey", "ginContextKey")
+	}
+	if template.Options.Client {
+		names = append(names, "httpClientField", "encodeHTTPClientPath", "httpClientFields", "encodeHTTPClientHeader", "addHTTPClientValue", "formatHTTPClientValue", "httpClientNoContent")
+		if len(template.StandaloneRoutes) > 0 {
+			names = append(names, parser.StandaloneServiceName+"HTTPClient", "New"+parser.StandaloneServiceName+"HTTPClient")
+		}
+	}
+	if template.Options.HasEnvelope() {
+		names = append(names, "newEnvelope", "writeEnvelope", "writeEnvelopeError", "writeEnvelopeBindError")
+		if template.Options.StandardEnvelope() {
+			names = append(names, parser.StandardEnvelopeType)
+		}
+	}
+	if template.Options.Validate {
+		names = append(names, "requestValidator", "RequestValidator", "newRequestValidator", "validateRequest")
+	}
+	if len(template.Validators) > 0 {
+		names = append(names, "RegisterValidators", "registerValidations", "registerValidatorTranslations", "customValidators", "regexValidator")
+	}
+	if template.Options.Docs {
+		names = append(names, "RegisterDocs", "openAPISpec", "docsAssets", "docsIndexHTML")
+	}
+	return names
+}
@@ -0,0 +1,139 @@
+package generator
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	ginparser "github.com/YuukiKazuto/kratosgin/internal/parser"
+)
+
+// namesSource 启用全部生成选项的模板
+const namesSource = `options {
+	packageName: v1
+	client: true
+	validate: true
+	envelope: standard
+	docs: true
+}
+
+validators {
+	phone regex "^1\\d{10}$"
+}
+
+enum Status int {
+	Active = 1
+}
+
+type (
+	Req {
+		ID int ` + "`uri:\"id\" binding:\"required,phone\"`" + `
+	}
+	Resp {}
+)
+
+service UserService {
+	middleware: ["auth"]
+	@Get GET /users/:id WithGinContext Req Resp
+	group @admin /admin {
+		@List GET /users - Resp
+	}
+}
+
+group @order /orders {
+	middleware: ["auth"]
+	@ListOrders GET / - Resp
+}
+
+@Ping GET /ping - - middleware: ["log"]
+`
+
+// TestFixedNames 生成全部文件，检查生成代码中的包级标识符除由模板声明得到的之外都在 FixedNames 中
+func TestFixedNames(t *testing.T) {
+	file, diags := ginparser.ParseFile("names.gin", namesSource)
+	if diags.HasErrors() {
+		t.Fatalf("解析失败:\n%v", diags)
+	}
+	template := ginparser.BuildTemplate(file)
+	template.Options.OutputDir = t.TempDir()
+	if err := NewCodeGenerator(template).Generate(); err != nil {
+		t.Fatal(err)
+	}
+
+	generated := make(map[string]bool)
+	paths, _ := filepath.Glob(filepath.Join(template.Options.OutputDir, "*.go"))
+	for _, path := range paths {
+		f, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for name := range packageNames(f) {
+			generated[name] = true
+		}
+	}
+
+	// 由模板中的类型、枚举、服务和分组得到的标识符
+	declared := map[string]bool{
+		"Req": true, "Resp": true, "Status": true, "StatusActive": true,
+		"UserService": true, "UserServiceHandler": true, "UserServiceMiddleware": true,
+		"UserServiceHTTPClient": true, "NewUserServiceHTTPClient": true,
+		"OrderService": true, "OrderHandler": true, "OrderMiddleware": true,
+		"OrderServiceHTTPClient": true, "NewOrderServiceHTTPClient": true,
+		"NewUserServiceHandler": true, "NewOrderHandler": true, "NewStandaloneHandler": true,
+	}
+
+	for name := range declared {
+		if !generated[name] {
+			t.Errorf("生成的代码中没有 %s，测试模板没有覆盖对应的声明", name)
+		}
+	}
+
+	fixed := make(map[string]bool)
+	for _, name := range FixedNames(template) {
+		fixed[name] = true
+		if !generated[name] {
+			t.Errorf("FixedNames 中的 %s 不在生成的代码中", name)
+		}
+	}
+	var missing []string
+	for name := range generated {
+		if !fixed[name] && !declared[name] {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	if len(missing) > 0 {
+		t.Errorf("生成代码中的包级标识符 %s 不在 FixedNames 中", strings.Join(missing, ", "))
+	}
+}
+
+// packageNames 返回文件中的包级标识符，不包括方法
+func packageNames(f *ast.File) map[string]bool {
+	names := make(map[string]bool)
+	for _, decl := range f.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv == nil {
+				names[decl.Name.Name] = true
+			}
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					names[spec.Name.Name] = true
+				case *ast.ValueSpec:
+					for _, name := range spec.Names {
+						if name.Name != "_" {
+							names[name.Name] = true
+						}
+					}
+				}
+			}
+		}
+	}
+	return names
+}
@@ -1,6 +1,7 @@
 package generator
 
 import (
+	"bytes"
 	_ "embed"
 	"fmt"
 	"os"
@@ -40,8 +41,8 @@ func (g *CodeGenerator) generateServiceImplementations() error {
 		return fmt.Errorf("failed to create service output directory: %w", err)
 	}
 
-	// 为每个服务生成实现
-	for _, service := range g.template.Services {
+	// 为每个服务（包括顶级分组和独立路由）生成实现
+	for _, service := range g.template.AllServices() {
 		if err := g.generateSingleServiceImplementation(service, absoluteServiceDir); err != nil {
 			return fmt.Errorf("failed to generate service implementation for %s: %w", service.Name, err)
 		}
@@ -58,10 +59,22 @@ func (g *CodeGenerator) generateSingleServiceImplementation(service parser.Servi
 	packageAlias := g.generatePackageAlias(apiPath, packageName)
 
 	// 收集所有方法（包括直接方法和路由分组中的方法）
-	allMethods := make([]parser.Method, 0)
-	allMethods = append(allMethods, service.Methods...)
-	for _, group := range service.RouteGroups {
-		allMethods = append(allMethods, group.Methods...)
+	allMethods := service.AllMethods()
+
+	// 写入文件
+	// 去掉 Service 后缀
+	serviceName := service.Name
+	serviceName = strings.TrimSuffix(serviceName, "Service")
+	filename := fmt.Sprintf("%s.go", strings.ToLower(serviceName))
+	filepath := filepath.Join(outputDir, filename)
+
+	// 已有文件沿用其中 API 包的导入名
+	_, statErr := os.Stat(filepath)
+	exists := statErr == nil
+	if exists {
+		if name := existingImportName(filepath, g.apiImportPath()); name != "" {
+			packageAlias = name
+		}
 	}
 
 	templateData := struct {
@@ -88,26 +101,39 @@ func (g *CodeGenerator) generateSingleServiceImplementation(service parser.Servi
 		return err
 	}
 
-	// 写入文件
-	// 去掉 Service 后缀
-	serviceName := service.Name
-	serviceName = strings.TrimSuffix(serviceName, "Service")
-	filename := fmt.Sprintf("%s.go", strings.ToLower(serviceName))
-	filepath := filepath.Join(outputDir, filename)
+	var content bytes.Buffer
+	if err := t.Execute(&content, templateData); err != nil {
+		return err
+	}
 
-	// 检查文件是否已存在
-	if _, err := os.Stat(filepath); err == nil {
-		fmt.Printf("Service 文件已存在，跳过生成: %s\n", filepath)
+	// 文件已存在时只合并缺少的方法和变化的签名，保留已有的实现
+	if exists {
+		result, err := mergeImplementation(filepath, service.Name, content.Bytes())
+		if err != nil {
+			return fmt.Errorf("合并 %s 失败: %w", filepath, err)
+		}
+		printMergeResult("Service 文件", filepath, result)
 		return nil
 	}
 
-	file, err := os.Create(filepath)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
+	return os.WriteFile(filepath, content.Bytes(), 0644)
+}
 
-	return t.Execute(file, templateData)
+// printMergeResult 输出合并已有实现文件的结果
+func printMergeResult(kind, filepath string, result mergeResult) {
+	if !result.changed() && len(result.Removed) == 0 {
+		fmt.Printf("%s已是最新: %s\n", kind, filepath)
+		return
+	}
+	if len(result.Added) > 0 {
+		fmt.Printf("%s新增方法: %s (%s)\n", kind, filepath, strings.Join(result.Added, ", "))
+	}
+	if len(result.Updated) > 0 {
+		fmt.Printf("%s更新方法签名: %s (%s)\n", kind, filepath, strings.Join(result.Updated, ", "))
+	}
+	if len(result.Removed) > 0 {
+		fmt.Printf("警告: %s 中的方法已不在 .gin 文件中，请确认后手动删除: %s\n", filepath, strings.Join(result.Removed, ", "))
+	}
 }
 
 // inferAPIPathAndPackage 推断 API 路径和包名
@@ -139,6 +165,12 @@ func (g *CodeGenerator) inferAPIPathAndPackage() (string, string) {
 	return "user", packageName // 使用模板中的包名
 }
 
+// apiImportPath 返回生成的 API 包的导入路径，例如 example/api/user/v1
+func (g *CodeGenerator) apiImportPath() string {
+	apiPath, packageName := g.inferAPIPathAndPackage()
+	return fmt.Sprintf("%s/api/%s/%s", g.inferModuleName(), apiPath, packageName)
+}
+
 // generatePackageAlias 生成包别名
 func (g *CodeGenerator) generatePackageAlias(apiPath, packageName string) string {
 	// 例如: user + v1 -> userV1
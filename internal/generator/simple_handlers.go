@@ -3,8 +3,10 @@ package generator
 import (
 	_ "embed"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"text/template"
 	"unicode"
@@ -55,206 +57,95 @@ func (g *CodeGenerator) generateHTTPHandlers() error {
 func (g *CodeGenerator) generateServiceHandlerWithGroups(service parser.Service) string {
 	var result strings.Builder
 
-	// 收集所有中间件名称（包括服务级别和路由组级别）
-	middlewareSet := make(map[string]bool)
-
-	// 收集服务级别中间件
-	for _, middleware := range service.Middleware {
-		cleanMiddleware := strings.Trim(middleware, `"'`)
-		if cleanMiddleware != "" {
-			middlewareSet[cleanMiddleware] = true
-		}
-	}
-
-	// 收集路由组级别中间件
-	for _, group := range service.RouteGroups {
-		for _, middleware := range group.Middleware {
-			cleanMiddleware := strings.Trim(middleware, `"'`)
-			if cleanMiddleware != "" {
-				middlewareSet[cleanMiddleware] = true
-			}
-		}
-		for _, method := range group.Methods {
-			for _, middleware := range method.Middleware {
-				cleanMiddleware := strings.Trim(middleware, `"'`)
-				if cleanMiddleware != "" {
-					middlewareSet[cleanMiddleware] = true
-				}
-			}
-		}
-	}
-
-	// 生成中间件接口
-	if len(middlewareSet) > 0 {
-		result.WriteString("// Middleware 中间件接口\n")
-		result.WriteString("type Middleware interface {\n")
-		for middleware := range middlewareSet {
-			cleanName := strings.Trim(middleware, `"'`)
-			result.WriteString(fmt.Sprintf("\t%s() gin.HandlerFunc\n", strings.Title(cleanName)))
-		}
-		result.WriteString("}\n\n")
-	}
+	// 生成服务的中间件接口（服务、分组和方法级别）
+	owner := serviceMiddlewareOwner(service)
+	writeMiddlewareInterface(&result, owner)
 
 	// 生成处理器结构体
 	result.WriteString(fmt.Sprintf("// %sHandler %s 处理器\n", service.Name, service.Name))
 	result.WriteString(fmt.Sprintf("type %sHandler struct {\n", service.Name))
 	result.WriteString("\tlog *log.Helper\n")
-	if len(middlewareSet) > 0 {
-		result.WriteString("\tmiddleware Middleware\n")
+	if owner.hasMiddleware() {
+		result.WriteString(fmt.Sprintf("\tmiddleware %s\n", owner.Interface))
 	}
 	result.WriteString(fmt.Sprintf("\t%s %s\n", toCamelCase(service.Name), service.Name))
 	result.WriteString("\ttranslator ut.Translator\n")
+	result.WriteString("\toptions handlerOptions\n")
 	result.WriteString("}\n\n")
 
 	// 生成构造函数
 	result.WriteString(fmt.Sprintf("// New%sHandler 创建 %s 处理器\n", service.Name, service.Name))
 	result.WriteString(fmt.Sprintf("func New%sHandler(logger log.Logger", service.Name))
-	if len(middlewareSet) > 0 {
-		result.WriteString(", middleware Middleware")
+	if owner.hasMiddleware() {
+		result.WriteString(", middleware " + owner.Interface)
 	}
 	result.WriteString(fmt.Sprintf(", %s %s", toCamelCase(service.Name), service.Name))
-	result.WriteString(", translator ut.Translator")
+	result.WriteString(", translator ut.Translator, opts ...HandlerOption")
 	result.WriteString(fmt.Sprintf(") *%sHandler {\n", service.Name))
 	result.WriteString("\treturn &")
 	result.WriteString(fmt.Sprintf("%sHandler{\n", service.Name))
 	result.WriteString("\t\tlog: log.NewHelper(logger),\n")
-	if len(middlewareSet) > 0 {
+	if owner.hasMiddleware() {
 		result.WriteString("\t\tmiddleware: middleware,\n")
 	}
 	result.WriteString(fmt.Sprintf("\t\t%s: %s,\n", toCamelCase(service.Name), toCamelCase(service.Name)))
 	result.WriteString("\t\ttranslator: translator,\n")
+	result.WriteString("\t\toptions: newHandlerOptions(opts),\n")
 	result.WriteString("\t}\n")
 	result.WriteString("}\n\n")
 
 	// 生成路由注册方法
 	result.WriteString("// RegisterRoutes 注册路由\n")
 	result.WriteString(fmt.Sprintf("func (h *%sHandler) RegisterRoutes(r *gin.Engine) {\n", service.Name))
+	// 每条路由注册完整的中间件链，而不是在路由组上调用 Use，
+	// 这样分组和方法可以通过 skip 去掉上级的中间件，服务级中间件也不会影响其他服务的路由
+	serviceChain := middlewareChain(nil, service.Middleware, nil)
+	var routes strings.Builder
+	vars := &middlewareVars{}
+
 	// 创建顶级路由组（如果有 prefix）
-	var topLevelGroup string
-	if service.Prefix != "" {
-		topLevelGroup = "PrefixGroup"
-		result.WriteString(fmt.Sprintf("\t%s := r.Group(\"/%s\")\n", topLevelGroup, service.Prefix))
-		result.WriteString("\t{\n")
-
-		// 应用服务级别中间件
-		for _, middleware := range service.Middleware {
-			cleanMiddleware := strings.Trim(middleware, `"'`)
-			if cleanMiddleware != "" {
-				result.WriteString(fmt.Sprintf("\t\t%s.Use(h.middleware.%s())\n",
-					topLevelGroup, strings.Title(cleanMiddleware)))
-			}
-		}
-	} else {
-		// 如果没有 prefix，直接在根路由上应用服务级别中间件
-		for _, middleware := range service.Middleware {
-			cleanMiddleware := strings.Trim(middleware, `"'`)
-			if cleanMiddleware != "" {
-				result.WriteString(fmt.Sprintf("\tr.Use(h.middleware.%s())\n", strings.Title(cleanMiddleware)))
-			}
-		}
+	router := "r"
+	indent := "\t"
+	if service.Prefix != "" && len(service.AllMethods()) > 0 {
+		router = "PrefixGroup"
+		indent = "\t\t"
+		routes.WriteString(fmt.Sprintf("\t%s := r.Group(\"/%s\")\n", router, service.Prefix))
+		routes.WriteString("\t{\n")
 	}
 
 	// 注册非分组路由
 	for _, method := range service.Methods {
-		router := "r"
-		indent := "\t"
-		if topLevelGroup != "" {
-			router = topLevelGroup
-			indent = "\t\t"
-		}
-
-		result.WriteString(fmt.Sprintf("%s%s.%s(\"%s\", h.%s)\n",
-			indent, router, strings.ToUpper(method.HTTPMethod), method.Path, method.Name))
+		chain := middlewareChain(serviceChain, method.Middleware, method.Skip)
+		routes.WriteString(fmt.Sprintf("%s%s.%s(\"%s\", %s)\n",
+			indent, router, strings.ToUpper(method.HTTPMethod), method.Path, vars.handlerChain(chain, method.Name)))
 	}
 
 	// 注册分组路由
 	for _, group := range service.RouteGroups {
-		groupVarName := strings.Title(group.Name) + "Group"
-
-		parentRouter := "r"
-		indent := "\t"
-		if topLevelGroup != "" {
-			parentRouter = topLevelGroup
-			indent = "\t\t"
-		}
-
-		result.WriteString(fmt.Sprintf("\n%s%s := %s.Group(\"%s\")\n", indent, groupVarName, parentRouter, group.Path))
-		result.WriteString(fmt.Sprintf("%s{\n", indent))
-
-		// 应用组级中间件（避免重复应用服务级别中间件）
-		serviceMiddlewareSet := make(map[string]bool)
-		for _, middleware := range service.Middleware {
-			cleanMiddleware := strings.Trim(middleware, `"'`)
-			if cleanMiddleware != "" {
-				serviceMiddlewareSet[cleanMiddleware] = true
-			}
-		}
-
-		for _, middleware := range group.Middleware {
-			cleanMiddleware := strings.Trim(middleware, `"'`)
-			// 过滤掉注释和无效的中间件名称，如果组中间件不在服务级别中间件中，才应用
-			if cleanMiddleware != "" && !serviceMiddlewareSet[cleanMiddleware] {
-				result.WriteString(fmt.Sprintf("%s\t%s.Use(h.middleware.%s())\n",
-					indent, groupVarName, strings.Title(cleanMiddleware)))
-			}
-		}
-
-		// 注册组内路由
-		for _, method := range group.Methods {
-			middlewareChain := ""
-			for _, middleware := range method.Middleware {
-				cleanMiddleware := strings.Trim(middleware, `"'`)
-				middlewareChain += fmt.Sprintf("h.middleware.%s(), ", strings.Title(cleanMiddleware))
-			}
-			result.WriteString(fmt.Sprintf("%s\t%s.%s(\"%s\", %sh.%s)\n",
-				indent, groupVarName, strings.ToUpper(method.HTTPMethod), method.Path, middlewareChain, method.Name))
-		}
-
-		result.WriteString(fmt.Sprintf("%s}\n", indent))
+		writeRouteGroup(&routes, vars, router, "", indent, group, serviceChain)
 	}
 
 	// 关闭顶级路由组
-	if topLevelGroup != "" {
-		result.WriteString("\t}\n")
+	if router != "r" {
+		routes.WriteString("\t}\n")
 	}
 
+	result.WriteString(vars.declarations())
+	result.WriteString(routes.String())
 	result.WriteString("}\n\n")
 
 	// 生成处理器方法
-	allMethods := append(service.Methods, []parser.Method{}...)
-	for _, group := range service.RouteGroups {
-		allMethods = append(allMethods, group.Methods...)
-	}
-
-	for _, method := range allMethods {
+	for _, method := range service.AllMethods() {
 		result.WriteString(fmt.Sprintf("// %s %s\n", method.Name, method.Description))
 		result.WriteString(fmt.Sprintf("func (h *%sHandler) %s(c *gin.Context) {\n", service.Name, method.Name))
 
 		// 绑定请求
-		result.WriteString(fmt.Sprintf("\treq := &%s{}\n", method.Request))
-		result.WriteString("\tif err := c.ShouldBind(req); err != nil {\n")
-		result.WriteString("\t\terr = translateValidationError(err, h.translator)\n")
-		result.WriteString(fmt.Sprintf("\t\th.log.Errorw(\"Struct\", \"%sHandler\", \"method\", \"%s\", \"error\", err)\n", service.Name, method.Name))
-		result.WriteString("\t\tc.JSON(http.StatusBadRequest, gin.H{\n")
-		result.WriteString("\t\t\t\"message\": err.Error(),\n")
-		result.WriteString("\t\t})\n")
-		result.WriteString("\t\treturn\n")
-		result.WriteString("\t}\n\n")
-
-		// 调用服务
-		if method.WithGinContext {
-			result.WriteString("\tctx := SaveToContext(c.Request.Context(), c)\n")
-		} else {
-			result.WriteString("\tctx := c.Request.Context()\n")
+		if method.HasRequest() {
+			g.writeBindRequest(&result, method, service.Name+"Handler")
 		}
-		result.WriteString(fmt.Sprintf("\tresp, err := h.%s.%s(ctx, req)\n", toCamelCase(service.Name), strings.Title(method.Name)))
-		result.WriteString("\tif err != nil {\n")
-		result.WriteString("\t\tkgin.Error(c, err)\n")
-		result.WriteString("\t\treturn\n")
-		result.WriteString("\t}\n\n")
 
-		// 返回响应
-		result.WriteString("\tc.JSON(http.StatusOK, resp)\n")
+		// 调用服务并返回响应
+		writeCallService(&result, method, toCamelCase(service.Name))
 		result.WriteString("}\n\n")
 	}
 
@@ -265,140 +156,321 @@ func (g *CodeGenerator) generateServiceHandlerWithGroups(service parser.Service)
 func (g *CodeGenerator) generateRouteGroupHandler(group parser.RouteGroup) string {
 	var result strings.Builder
 
-	// 收集所有中间件名称
-	middlewareSet := make(map[string]bool)
-	for _, middleware := range group.Middleware {
-		cleanMiddleware := strings.Trim(middleware, `"'`)
-		middlewareSet[cleanMiddleware] = true
-	}
-	for _, method := range group.Methods {
-		for _, middleware := range method.Middleware {
-			cleanMiddleware := strings.Trim(middleware, `"'`)
-			middlewareSet[cleanMiddleware] = true
-		}
-	}
+	// 顶级分组作为独立的服务，处理器和服务接口分别命名为 <Group>Handler 和 <Group>Service
+	handlerName := strings.Title(group.Name) + "Handler"
+	serviceName := parser.GroupServiceName(group.Name)
 
-	// 生成中间件接口
-	if len(middlewareSet) > 0 {
-		result.WriteString("// Middleware 中间件接口\n")
-		result.WriteString("type Middleware interface {\n")
-		for middleware := range middlewareSet {
-			cleanName := strings.Trim(middleware, `"'`)
-			result.WriteString(fmt.Sprintf("\t%s() gin.HandlerFunc\n", strings.Title(cleanName)))
-		}
-		result.WriteString("}\n\n")
-	}
+	// 生成分组的中间件接口
+	owner := groupMiddlewareOwner(group)
+	writeMiddlewareInterface(&result, owner)
 
 	// 生成处理器结构体
-	result.WriteString(fmt.Sprintf("// %sHandler %s 处理器\n", group.Name, group.Name))
-	result.WriteString(fmt.Sprintf("type %sHandler struct {\n", group.Name))
+	result.WriteString(fmt.Sprintf("// %s %s 分组处理器\n", handlerName, group.Name))
+	result.WriteString(fmt.Sprintf("type %s struct {\n", handlerName))
 	result.WriteString("\tlog *log.Helper\n")
-	if len(middlewareSet) > 0 {
-		result.WriteString("\tmiddleware Middleware\n")
+	if owner.hasMiddleware() {
+		result.WriteString(fmt.Sprintf("\tmiddleware %s\n", owner.Interface))
 	}
+	result.WriteString(fmt.Sprintf("\t%s %s\n", toCamelCase(serviceName), serviceName))
 	result.WriteString("\ttranslator ut.Translator\n")
+	result.WriteString("\toptions handlerOptions\n")
 	result.WriteString("}\n\n")
 
 	// 生成构造函数
-	result.WriteString(fmt.Sprintf("// New%sHandler 创建 %s 处理器\n", group.Name, group.Name))
-	result.WriteString(fmt.Sprintf("func New%sHandler(logger log.Logger", group.Name))
-	if len(middlewareSet) > 0 {
-		result.WriteString(", middleware Middleware")
+	result.WriteString(fmt.Sprintf("// New%s 创建 %s 分组处理器\n", handlerName, group.Name))
+	result.WriteString(fmt.Sprintf("func New%s(logger log.Logger", handlerName))
+	if owner.hasMiddleware() {
+		result.WriteString(", middleware " + owner.Interface)
 	}
-	result.WriteString(", translator ut.Translator")
-	result.WriteString(fmt.Sprintf(") *%sHandler {\n", group.Name))
+	result.WriteString(fmt.Sprintf(", %s %s", toCamelCase(serviceName), serviceName))
+	result.WriteString(", translator ut.Translator, opts ...HandlerOption")
+	result.WriteString(fmt.Sprintf(") *%s {\n", handlerName))
 	result.WriteString("\treturn &")
-	result.WriteString(fmt.Sprintf("%sHandler{\n", group.Name))
+	result.WriteString(fmt.Sprintf("%s{\n", handlerName))
 	result.WriteString("\t\tlog: log.NewHelper(logger),\n")
-	if len(middlewareSet) > 0 {
+	if owner.hasMiddleware() {
 		result.WriteString("\t\tmiddleware: middleware,\n")
 	}
+	result.WriteString(fmt.Sprintf("\t\t%s: %s,\n", toCamelCase(serviceName), toCamelCase(serviceName)))
 	result.WriteString("\t\ttranslator: translator,\n")
+	result.WriteString("\t\toptions: newHandlerOptions(opts),\n")
 	result.WriteString("\t}\n")
 	result.WriteString("}\n\n")
 
 	// 生成路由注册方法
 	result.WriteString("// RegisterRoutes 注册路由\n")
-	result.WriteString(fmt.Sprintf("func (h *%sHandler) RegisterRoutes(r *gin.Engine) {\n", group.Name))
+	result.WriteString(fmt.Sprintf("func (h *%s) RegisterRoutes(r *gin.Engine) {\n", handlerName))
+
+	var routes strings.Builder
+	vars := &middlewareVars{}
+	writeRouteGroup(&routes, vars, "r", "", "\t", group, nil)
+	result.WriteString(vars.declarations())
+	result.WriteString(strings.TrimPrefix(routes.String(), "\n"))
+	result.WriteString("}\n\n")
 
-	groupVarName := strings.Title(group.Name) + "Group"
-	result.WriteString(fmt.Sprintf("\t%s := r.Group(\"%s\")\n", groupVarName, group.Path))
-	result.WriteString("\t{\n")
+	// 生成处理器方法
+	for _, method := range group.AllMethods() {
+		result.WriteString(fmt.Sprintf("// %s %s\n", method.Name, method.Description))
+		result.WriteString(fmt.Sprintf("func (h *%s) %s(c *gin.Context) {\n", handlerName, method.Name))
+
+		// 绑定请求
+		if method.HasRequest() {
+			g.writeBindRequest(&result, method, handlerName)
+		}
 
-	// 应用组级中间件
-	for _, middleware := range group.Middleware {
-		cleanMiddleware := strings.Trim(middleware, `"'`)
-		result.WriteString(fmt.Sprintf("\t\t%s.Use(h.middleware.%s())\n",
-			groupVarName, strings.Title(cleanMiddleware)))
+		// 调用服务并返回响应
+		writeCallService(&result, method, toCamelCase(serviceName))
+		result.WriteString("}\n\n")
 	}
 
+	return result.String()
+}
+
+// routeGroupVarName 返回分组在 RegisterRoutes 中的变量名，子分组以上级分组名为前缀
+func routeGroupVarName(parentVar, name string) string {
+	return strings.TrimSuffix(parentVar, "Group") + strings.Title(name) + "Group"
+}
+
+// writeRouteGroup 递归生成路由组及其子分组的注册代码
+// inherited 为上级路由的中间件链，分组在此基础上去掉 skip 的中间件并追加自身的中间件，没有路由的分组不生成
+func writeRouteGroup(result *strings.Builder, vars *middlewareVars, parentRouter, parentVarName, indent string, group parser.RouteGroup, inherited []string) {
+	if len(group.AllMethods()) == 0 {
+		return
+	}
+	groupVarName := routeGroupVarName(parentVarName, group.Name)
+	chain := middlewareChain(inherited, group.Middleware, group.Skip)
+
+	result.WriteString(fmt.Sprintf("\n%s%s := %s.Group(\"%s\")\n", indent, groupVarName, parentRouter, group.Path))
+	result.WriteString(fmt.Sprintf("%s{\n", indent))
+
 	// 注册组内路由
 	for _, method := range group.Methods {
-		middlewareChain := ""
-		for _, middleware := range method.Middleware {
-			cleanMiddleware := strings.Trim(middleware, `"'`)
-			middlewareChain += fmt.Sprintf("h.middleware.%s(), ", strings.Title(cleanMiddleware))
-		}
-		result.WriteString(fmt.Sprintf("\t\t%s.%s(\"%s\", %sh.%s)\n",
-			groupVarName, strings.ToUpper(method.HTTPMethod), method.Path, middlewareChain, method.Name))
+		methodChain := middlewareChain(chain, method.Middleware, method.Skip)
+		result.WriteString(fmt.Sprintf("%s\t%s.%s(\"%s\", %s)\n",
+			indent, groupVarName, strings.ToUpper(method.HTTPMethod), method.Path, vars.handlerChain(methodChain, method.Name)))
 	}
 
-	result.WriteString("\t}\n")
-	result.WriteString("}\n\n")
+	// 注册子分组
+	for _, child := range group.Groups {
+		writeRouteGroup(result, vars, groupVarName, groupVarName, indent+"\t", child, chain)
+	}
 
-	// 生成处理器方法
-	for _, method := range group.Methods {
-		result.WriteString(fmt.Sprintf("// %s %s\n", method.Name, method.Description))
-		result.WriteString(fmt.Sprintf("func (h *%sHandler) %s(c *gin.Context) {\n", group.Name, method.Name))
+	result.WriteString(fmt.Sprintf("%s}\n", indent))
+}
 
-		// 绑定请求
-		result.WriteString(fmt.Sprintf("\treq := &%s{}\n", method.Request))
-		result.WriteString("\tif err := c.ShouldBind(req); err != nil {\n")
-		result.WriteString("\t\terr = translateValidationError(err, h.translator)\n")
-		result.WriteString(fmt.Sprintf("\t\th.log.Errorw(\"Struct\", \"%sHandler\", \"method\", \"%s\", \"error\", err)\n", group.Name, method.Name))
-		result.WriteString("\t\tc.JSON(http.StatusBadRequest, gin.H{\n")
-		result.WriteString("\t\t\t\"message\": err.Error(),\n")
-		result.WriteString("\t\t})\n")
-		result.WriteString("\t\treturn\n")
-		result.WriteString("\t}\n\n")
-
-		// 调用服务
-		if method.WithGinContext {
-			result.WriteString("\tctx := SaveToContext(c.Request.Context(), c)\n")
-		} else {
-			result.WriteString("\tctx := c.Request.Context()\n")
+// middlewareChain 计算中间件链：继承上级的中间件，去掉 skip 的中间件，再追加自身的中间件
+func middlewareChain(inherited, middleware, skip []string) []string {
+	skipped := make(map[string]bool)
+	for _, name := range skip {
+		skipped[strings.Trim(name, `"'`)] = true
+	}
+
+	chain := make([]string, 0, len(inherited)+len(middleware))
+	seen := make(map[string]bool)
+	add := func(name string) {
+		name = strings.Trim(name, `"'`)
+		if name != "" && !seen[name] {
+			seen[name] = true
+			chain = append(chain, name)
 		}
-		result.WriteString(fmt.Sprintf("\tresp, err := h.%s.%s(ctx, req)\n", toCamelCase(group.Name), method.Name))
-		result.WriteString("\tif err != nil {\n")
-		result.WriteString("\t\tkgin.Error(c, err)\n")
-		result.WriteString("\t\treturn\n")
-		result.WriteString("\t}\n\n")
+	}
+	for _, name := range inherited {
+		if !skipped[name] {
+			add(name)
+		}
+	}
+	for _, name := range middleware {
+		add(name)
+	}
+	return chain
+}
 
-		// 返回响应
-		result.WriteString("\tc.JSON(http.StatusOK, resp)\n")
-		result.WriteString("}\n\n")
+// middlewareVars 记录 RegisterRoutes 中用到的中间件，每个中间件只创建一次，由各路由共享
+type middlewareVars struct {
+	names []string
+	seen  map[string]bool
+}
+
+// handlerChain 生成路由注册时的处理函数列表，中间件在前，处理器方法在最后
+func (v *middlewareVars) handlerChain(middleware []string, handler string) string {
+	if v.seen == nil {
+		v.seen = make(map[string]bool)
+	}
+	var chain strings.Builder
+	for _, name := range middleware {
+		if !v.seen[name] {
+			v.seen[name] = true
+			v.names = append(v.names, name)
+		}
+		chain.WriteString(middlewareVarName(name) + ", ")
 	}
+	chain.WriteString("h." + handler)
+	return chain.String()
+}
 
+// declarations 生成中间件变量的声明
+func (v *middlewareVars) declarations() string {
+	var result strings.Builder
+	for _, name := range v.names {
+		result.WriteString(fmt.Sprintf("\t%s := h.middleware.%s()\n", middlewareVarName(name), strings.Title(name)))
+	}
+	if len(v.names) > 0 {
+		result.WriteString("\n")
+	}
 	return result.String()
 }
 
+// middlewareVarName 返回中间件在 RegisterRoutes 中的变量名
+func middlewareVarName(name string) string {
+	return toCamelCase(name) + "Middleware"
+}
+
+// middlewareList 按首次出现的顺序收集中间件名称，保证生成结果稳定
+type middlewareList struct {
+	names []string
+	seen  map[string]bool
+}
+
+func (l *middlewareList) add(names ...string) {
+	if l.seen == nil {
+		l.seen = make(map[string]bool)
+	}
+	for _, name := range names {
+		name = strings.Trim(name, `"'`)
+		if name != "" && !l.seen[name] {
+			l.seen[name] = true
+			l.names = append(l.names, name)
+		}
+	}
+}
+
+func (l *middlewareList) addMethods(methods []parser.Method) {
+	for _, method := range methods {
+		l.add(method.Middleware...)
+	}
+}
+
+// addGroup 收集分组、子分组和其中方法的中间件
+func (l *middlewareList) addGroup(group parser.RouteGroup) {
+	l.add(group.Middleware...)
+	l.addMethods(group.Methods)
+	for _, child := range group.Groups {
+		l.addGroup(child)
+	}
+}
+
+// addService 收集服务、分组和方法级别的中间件
+func (l *middlewareList) addService(service parser.Service) {
+	l.add(service.Middleware...)
+	l.addMethods(service.Methods)
+	for _, group := range service.RouteGroups {
+		l.addGroup(group)
+	}
+}
+
+// middlewareOwner 表示拥有独立中间件接口的处理器：服务、顶级分组或独立路由
+type middlewareOwner struct {
+	Name      string   // 中间件实现的名称，实现结构体为 <Name>Middleware，文件为 <name>.go
+	Interface string   // 生成的中间件接口名
+	Names     []string // 用到的中间件，按首次出现的顺序
+}
+
+func (o middlewareOwner) hasMiddleware() bool {
+	return len(o.Names) > 0
+}
+
+// serviceMiddlewareOwner 返回服务的中间件接口信息，例如 UserService -> UserServiceMiddleware
+func serviceMiddlewareOwner(service parser.Service) middlewareOwner {
+	list := &middlewareList{}
+	list.addService(service)
+	name := strings.TrimSuffix(service.Name, "Service")
+	if name == "" {
+		name = service.Name
+	}
+	return middlewareOwner{Name: name, Interface: service.Name + "Middleware", Names: list.names}
+}
+
+// groupMiddlewareOwner 返回顶级分组的中间件接口信息，例如 admin -> AdminMiddleware
+func groupMiddlewareOwner(group parser.RouteGroup) middlewareOwner {
+	list := &middlewareList{}
+	list.addGroup(group)
+	name := strings.Title(group.Name)
+	return middlewareOwner{Name: name, Interface: name + "Middleware", Names: list.names}
+}
+
+// standaloneMiddlewareOwner 返回独立路由的中间件接口信息
+func standaloneMiddlewareOwner(routes []parser.StandaloneRoute) middlewareOwner {
+	list := &middlewareList{}
+	for _, route := range routes {
+		list.add(route.Middleware...)
+	}
+	return middlewareOwner{Name: "Standalone", Interface: "StandaloneMiddleware", Names: list.names}
+}
+
+// middlewareOwners 按模板中的顺序返回所有服务、顶级分组和独立路由的中间件接口信息
+func middlewareOwners(template *parser.GinTemplate) []middlewareOwner {
+	var owners []middlewareOwner
+	for _, service := range template.Services {
+		owners = append(owners, serviceMiddlewareOwner(service))
+	}
+	for _, group := range template.RouteGroups {
+		owners = append(owners, groupMiddlewareOwner(group))
+	}
+	if len(template.StandaloneRoutes) > 0 {
+		owners = append(owners, standaloneMiddlewareOwner(template.StandaloneRoutes))
+	}
+	return owners
+}
+
+// writeMiddlewareInterface 生成处理器的中间件接口，没有中间件时不生成
+func writeMiddlewareInterface(result *strings.Builder, owner middlewareOwner) {
+	if !owner.hasMiddleware() {
+		return
+	}
+	result.WriteString(fmt.Sprintf("// %s 中间件接口\n", owner.Interface))
+	result.WriteString(fmt.Sprintf("type %s interface {\n", owner.Interface))
+	for _, name := range owner.Names {
+		result.WriteString(fmt.Sprintf("\t%s() gin.HandlerFunc\n", strings.Title(name)))
+	}
+	result.WriteString("}\n\n")
+}
+
 // generateStandaloneRoutesHandler 生成独立路由处理器
 func (g *CodeGenerator) generateStandaloneRoutesHandler(routes []parser.StandaloneRoute) string {
 	var result strings.Builder
 
+	// 生成独立路由的中间件接口
+	owner := standaloneMiddlewareOwner(routes)
+	writeMiddlewareInterface(&result, owner)
+
 	// 生成处理器结构体
 	result.WriteString("// StandaloneHandler 独立路由处理器\n")
 	result.WriteString("type StandaloneHandler struct {\n")
 	result.WriteString("\tlog *log.Helper\n")
+	if owner.hasMiddleware() {
+		result.WriteString(fmt.Sprintf("\tmiddleware %s\n", owner.Interface))
+	}
+	result.WriteString(fmt.Sprintf("\t%s %s\n", toCamelCase(parser.StandaloneServiceName), parser.StandaloneServiceName))
 	result.WriteString("\ttranslator ut.Translator\n")
+	result.WriteString("\toptions handlerOptions\n")
 	result.WriteString("}\n\n")
 
 	// 生成构造函数
 	result.WriteString("// NewStandaloneHandler 创建独立路由处理器\n")
-	result.WriteString("func NewStandaloneHandler(logger log.Logger, translator ut.Translator) *StandaloneHandler {\n")
+	result.WriteString("func NewStandaloneHandler(logger log.Logger")
+	if owner.hasMiddleware() {
+		result.WriteString(", middleware " + owner.Interface)
+	}
+	result.WriteString(fmt.Sprintf(", %s %s", toCamelCase(parser.StandaloneServiceName), parser.StandaloneServiceName))
+	result.WriteString(", translator ut.Translator, opts ...HandlerOption) *StandaloneHandler {\n")
 	result.WriteString("\treturn &StandaloneHandler{\n")
 	result.WriteString("\t\tlog: log.NewHelper(logger),\n")
+	if owner.hasMiddleware() {
+		result.WriteString("\t\tmiddleware: middleware,\n")
+	}
+	result.WriteString(fmt.Sprintf("\t\t%s: %s,\n", toCamelCase(parser.StandaloneServiceName), toCamelCase(parser.StandaloneServiceName)))
 	result.WriteString("\t\ttranslator: translator,\n")
+	result.WriteString("\t\toptions: newHandlerOptions(opts),\n")
 	result.WriteString("\t}\n")
 	result.WriteString("}\n\n")
 
@@ -406,10 +478,15 @@ func (g *CodeGenerator) generateStandaloneRoutesHandler(routes []parser.Standalo
 	result.WriteString("// RegisterRoutes 注册路由\n")
 	result.WriteString("func (h *StandaloneHandler) RegisterRoutes(r *gin.Engine) {\n")
 
+	var registrations strings.Builder
+	vars := &middlewareVars{}
 	for _, route := range routes {
-		result.WriteString(fmt.Sprintf("\tr.%s(\"%s\", h.%s)\n",
-			strings.ToUpper(route.HTTPMethod), route.Path, route.Name))
+		chain := middlewareChain(nil, route.Middleware, nil)
+		registrations.WriteString(fmt.Sprintf("\tr.%s(\"%s\", %s)\n",
+			strings.ToUpper(route.HTTPMethod), route.Path, vars.handlerChain(chain, route.Name)))
 	}
+	result.WriteString(vars.declarations())
+	result.WriteString(registrations.String())
 
 	result.WriteString("}\n\n")
 
@@ -419,32 +496,130 @@ func (g *CodeGenerator) generateStandaloneRoutesHandler(routes []parser.Standalo
 		result.WriteString(fmt.Sprintf("func (h *StandaloneHandler) %s(c *gin.Context) {\n", route.Name))
 
 		// 绑定请求
-		result.WriteString(fmt.Sprintf("\treq := &%s{}\n", route.Request))
-		result.WriteString("\tif err := c.ShouldBind(req); err != nil {\n")
-		result.WriteString("\t\terr = translateValidationError(err, h.translator)\n")
-		result.WriteString(fmt.Sprintf("\t\th.log.Errorw(\"Struct\", \"StandaloneHandler\", \"method\", \"%s\", \"error\", err)\n", route.Name))
-		result.WriteString("\t\tc.JSON(http.StatusBadRequest, gin.H{\n")
-		result.WriteString("\t\t\t\"message\": err.Error(),\n")
-		result.WriteString("\t\t})\n")
-		result.WriteString("\t\treturn\n")
-		result.WriteString("\t}\n\n")
-
-		// 调用服务
-		if route.WithGinContext {
-			result.WriteString("\tctx := SaveToContext(c.Request.Context(), c)\n")
-		} else {
-			result.WriteString("\tctx := c.Request.Context()\n")
+		if route.HasRequest() {
+			g.writeBindRequest(&result, route.Method, "StandaloneHandler")
 		}
-		result.WriteString(fmt.Sprintf("\tresp, err := h.%s.%s(ctx, req)\n", toCamelCase(route.Name), route.Name))
-		result.WriteString("\tif err != nil {\n")
-		result.WriteString("\t\tkgin.Error(c, err)\n")
-		result.WriteString("\t\treturn\n")
-		result.WriteString("\t}\n\n")
 
-		// 返回响应
-		result.WriteString("\tc.JSON(http.StatusOK, resp)\n")
+		// 调用服务并返回响应
+		writeCallService(&result, route.Method, toCamelCase(parser.StandaloneServiceName))
 		result.WriteString("}\n\n")
 	}
 
 	return result.String()
 }
+
+// methodRoute 返回方法展开后的路由，包含服务前缀和分组路径
+func (g *CodeGenerator) methodRoute(method parser.Method) parser.Route {
+	for _, route := range g.template.Routes() {
+		if route.Pos == method.Pos && route.Name == method.Name {
+			return route
+		}
+	}
+	return parser.Route{Method: method, FullPath: method.Path}
+}
+
+// writeBindRequest 生成绑定请求的代码
+// 按路径参数、查询参数、请求头、请求体的顺序绑定，只有最后一步使用 gin 的 ShouldBindXxx 做校验，
+// 之前的步骤只填充字段，避免请求体中的必填字段在绑定路径参数时就被校验
+func (g *CodeGenerator) writeBindRequest(result *strings.Builder, method parser.Method, handlerName string) {
+	binding := g.template.RequestBinding(g.methodRoute(method))
+
+	var steps []string
+	if binding.URI {
+		steps = append(steps, "bindURI(c, req)")
+	}
+	if binding.Body {
+		if binding.Query {
+			steps = append(steps, "bindQuery(c, req)")
+		}
+		if len(binding.Headers) > 0 {
+			steps = append(steps, fmt.Sprintf("bindHeader(c, req, %s)", quoteAll(binding.Headers)))
+		}
+		steps = append(steps, "c.ShouldBind(req)")
+	} else if len(binding.Headers) > 0 {
+		steps = append(steps, "bindQuery(c, req)", "c.ShouldBindHeader(req)")
+	} else {
+		steps = append(steps, "c.ShouldBindQuery(req)")
+	}
+
+	result.WriteString(fmt.Sprintf("\treq := &%s{}\n", method.Request))
+	if len(steps) == 1 {
+		result.WriteString(fmt.Sprintf("\tif err := %s; err != nil {\n", steps[0]))
+	} else {
+		result.WriteString(fmt.Sprintf("\terr := %s\n", steps[0]))
+		for _, step := range steps[1:] {
+			result.WriteString("\tif err == nil {\n")
+			result.WriteString(fmt.Sprintf("\t\terr = %s\n", step))
+			result.WriteString("\t}\n")
+		}
+		result.WriteString("\tif err != nil {\n")
+	}
+	result.WriteString("\t\terr = translateValidationError(err, req, h.options.requestTranslator(c, h.translator))\n")
+	result.WriteString(fmt.Sprintf("\t\th.log.Errorw(\"Struct\", \"%s\", \"method\", \"%s\", \"error\", err)\n", handlerName, method.Name))
+	result.WriteString("\t\th.options.bindErrorEncoder(c, err)\n")
+	result.WriteString("\t\treturn\n")
+	result.WriteString("\t}\n\n")
+}
+
+// statusConstants 成功状态码对应的 net/http 常量
+var statusConstants = map[int]string{
+	http.StatusOK:                   "http.StatusOK",
+	http.StatusCreated:              "http.StatusCreated",
+	http.StatusAccepted:             "http.StatusAccepted",
+	http.StatusNonAuthoritativeInfo: "http.StatusNonAuthoritativeInfo",
+	http.StatusNoContent:            "http.StatusNoContent",
+	http.StatusResetContent:         "http.StatusResetContent",
+	http.StatusPartialContent:       "http.StatusPartialContent",
+	http.StatusMultiStatus:          "http.StatusMultiStatus",
+	http.StatusAlreadyReported:      "http.StatusAlreadyReported",
+	http.StatusIMUsed:               "http.StatusIMUsed",
+}
+
+// statusExpr 返回状态码在生成代码中的表达式，没有对应常量时使用数字
+func statusExpr(status int) string {
+	if name, ok := statusConstants[status]; ok {
+		return name
+	}
+	return strconv.Itoa(status)
+}
+
+// writeCallService 生成调用服务方法并写入响应的代码，错误和响应由处理器选项中的编码器写入
+// 没有请求类型时不传 req，没有响应类型时服务方法只返回 error，传给编码器的响应为 nil
+func writeCallService(result *strings.Builder, method parser.Method, serviceField string) {
+	if method.WithGinContext {
+		result.WriteString("\tctx := SaveToContext(c.Request.Context(), c)\n")
+	} else {
+		result.WriteString("\tctx := c.Request.Context()\n")
+	}
+
+	args := "ctx"
+	if method.HasRequest() {
+		args += ", req"
+	}
+	call := fmt.Sprintf("h.%s.%s(%s)", serviceField, strings.Title(method.Name), args)
+	if method.HasResponse() {
+		result.WriteString(fmt.Sprintf("\tresp, err := %s\n", call))
+		result.WriteString("\tif err != nil {\n")
+	} else {
+		result.WriteString(fmt.Sprintf("\tif err := %s; err != nil {\n", call))
+	}
+	result.WriteString("\t\th.options.errorEncoder(c, err)\n")
+	result.WriteString("\t\treturn\n")
+	result.WriteString("\t}\n\n")
+
+	// 返回响应
+	resp := "nil"
+	if method.HasResponse() {
+		resp = "resp"
+	}
+	result.WriteString(fmt.Sprintf("\th.options.responseEncoder(c, %s, %s)\n", statusExpr(method.StatusCode()), resp))
+}
+
+// quoteAll 将字符串列表转换为逗号分隔的 Go 字符串字面量
+func quoteAll(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = strconv.Quote(value)
+	}
+	return strings.Join(quoted, ", ")
+}
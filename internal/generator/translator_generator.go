@@ -0,0 +1,38 @@
+package generator
+
+import (
+	"bytes"
+	_ "embed"
+	"go/format"
+	"os"
+	"path/filepath"
+	"text/template"
+)
+
+//go:embed templates/translator.tmpl
+var translatorTemplate string
+
+const translatorFileName = "translator.go"
+
+// generateTranslator 生成按请求语言选择校验错误翻译器所需的函数
+func (g *CodeGenerator) generateTranslator() error {
+	t, err := template.New("translator.tmpl").Parse(translatorTemplate)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, struct {
+		PackageName   string
+		HasValidators bool
+	}{
+		PackageName:   g.template.Options.PackageName,
+		HasValidators: len(g.template.Validators) > 0,
+	}); err != nil {
+		return err
+	}
+	content, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(g.template.Options.OutputDir, translatorFileName), content, 0644)
+}
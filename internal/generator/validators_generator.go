@@ -0,0 +1,58 @@
+package generator
+
+import (
+	"bytes"
+	_ "embed"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/YuukiKazuto/kratosgin/internal/parser"
+)
+
+//go:embed templates/validators.tmpl
+var validatorsTemplate string
+
+const validatorsFileName = "validators.go"
+
+// generateValidators 生成注册 validators 块中校验规则的 RegisterValidators
+// 没有声明校验规则时删除之前生成的文件
+func (g *CodeGenerator) generateValidators() error {
+	validatorsPath := filepath.Join(g.template.Options.OutputDir, validatorsFileName)
+	if len(g.template.Validators) == 0 {
+		return removeGeneratedFile(validatorsPath, generatedHeader, "校验规则文件")
+	}
+
+	t, err := template.New("validators.tmpl").Funcs(template.FuncMap{
+		"goString": goString,
+	}).Parse(validatorsTemplate)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, struct {
+		PackageName string
+		Validators  []parser.Validator
+	}{
+		PackageName: g.template.Options.PackageName,
+		Validators:  g.template.Validators,
+	}); err != nil {
+		return err
+	}
+	content, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(validatorsPath, content, 0644)
+}
+
+// goString 返回字符串的 Go 字面量，优先使用反引号以保持正则表达式可读
+func goString(s string) string {
+	if strconv.CanBackquote(s) && !strings.Contains(s, "`") {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
@@ -0,0 +1,197 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Version 生成的 OpenAPI 版本
+const Version = "3.1.0"
+
+// Document OpenAPI 文档
+type Document struct {
+	OpenAPI    string                 `yaml:"openapi" json:"openapi"`
+	Info       Info                   `yaml:"info" json:"info"`
+	Tags       []Tag                  `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Paths      *OrderedMap[*PathItem] `yaml:"paths" json:"paths"`
+	Components *Components            `yaml:"components,omitempty" json:"components,omitempty"`
+}
+
+// Info 文档基本信息，来自 .gin 文件的 info 块
+type Info struct {
+	Title       string `yaml:"title" json:"title"`
+	Version     string `yaml:"version" json:"version"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+}
+
+// Tag 接口分组
+type Tag struct {
+	Name string `yaml:"name" json:"name"`
+}
+
+// PathItem 同一路径下按 HTTP 方法（小写）区分的操作
+type PathItem = OrderedMap[*Operation]
+
+// Operation 一个接口
+type Operation struct {
+	Tags        []string               `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Summary     string                 `yaml:"summary,omitempty" json:"summary,omitempty"`
+	Description string                 `yaml:"description,omitempty" json:"description,omitempty"`
+	OperationID string                 `yaml:"operationId" json:"operationId"`
+	Parameters  []*Parameter           `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	RequestBody *RequestBody           `yaml:"requestBody,omitempty" json:"requestBody,omitempty"`
+	Responses   *OrderedMap[*Response] `yaml:"responses" json:"responses"`
+}
+
+// Parameter 路径、查询或请求头参数
+type Parameter struct {
+	Name        string  `yaml:"name" json:"name"`
+	In          string  `yaml:"in" json:"in"`
+	Description string  `yaml:"description,omitempty" json:"description,omitempty"`
+	Required    bool    `yaml:"required,omitempty" json:"required,omitempty"`
+	Schema      *Schema `yaml:"schema" json:"schema"`
+}
+
+// RequestBody 请求体
+type RequestBody struct {
+	Required bool                  `yaml:"required,omitempty" json:"required,omitempty"`
+	Content  map[string]*MediaType `yaml:"content" json:"content"`
+}
+
+// Response 响应
+type Response struct {
+	Description string                `yaml:"description" json:"description"`
+	Content     map[string]*MediaType `yaml:"content,omitempty" json:"content,omitempty"`
+}
+
+// MediaType 请求体或响应的内容
+type MediaType struct {
+	Schema *Schema `yaml:"schema" json:"schema"`
+}
+
+// Components 可复用的定义
+type Components struct {
+	Schemas *OrderedMap[*Schema] `yaml:"schemas,omitempty" json:"schemas,omitempty"`
+}
+
+// Schema JSON Schema（OpenAPI 3.1 使用 JSON Schema 2020-12）
+type Schema struct {
+	Ref                  string               `yaml:"$ref,omitempty" json:"$ref,omitempty"`
+	Type                 string               `yaml:"type,omitempty" json:"type,omitempty"`
+	Format               string               `yaml:"format,omitempty" json:"format,omitempty"`
+	Description          string               `yaml:"description,omitempty" json:"description,omitempty"`
+	Enum                 []interface{}        `yaml:"enum,omitempty" json:"enum,omitempty"`
+	EnumVarNames         []string             `yaml:"x-enum-varnames,omitempty" json:"x-enum-varnames,omitempty"`
+	Items                *Schema              `yaml:"items,omitempty" json:"items,omitempty"`
+	Properties           *OrderedMap[*Schema] `yaml:"properties,omitempty" json:"properties,omitempty"`
+	AdditionalProperties *Schema              `yaml:"additionalProperties,omitempty" json:"additionalProperties,omitempty"`
+	Required             []string             `yaml:"required,omitempty" json:"required,omitempty"`
+	AllOf                []*Schema            `yaml:"allOf,omitempty" json:"allOf,omitempty"`
+	Minimum              *float64             `yaml:"minimum,omitempty" json:"minimum,omitempty"`
+	Maximum              *float64             `yaml:"maximum,omitempty" json:"maximum,omitempty"`
+	ExclusiveMinimum     *float64             `yaml:"exclusiveMinimum,omitempty" json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     *float64             `yaml:"exclusiveMaximum,omitempty" json:"exclusiveMaximum,omitempty"`
+	MinLength            *int                 `yaml:"minLength,omitempty" json:"minLength,omitempty"`
+	MaxLength            *int                 `yaml:"maxLength,omitempty" json:"maxLength,omitempty"`
+	MinItems             *int                 `yaml:"minItems,omitempty" json:"minItems,omitempty"`
+	MaxItems             *int                 `yaml:"maxItems,omitempty" json:"maxItems,omitempty"`
+}
+
+// YAML 将文档编码为 YAML
+func (d *Document) YAML() ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(d); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// JSON 将文档编码为 JSON
+func (d *Document) JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// OrderedMap 按插入顺序输出的映射，保证路径和字段的顺序与 .gin 文件一致
+type OrderedMap[V any] struct {
+	keys   []string
+	values map[string]V
+}
+
+// NewOrderedMap 创建空的 OrderedMap
+func NewOrderedMap[V any]() *OrderedMap[V] {
+	return &OrderedMap[V]{values: make(map[string]V)}
+}
+
+// Set 设置键值，已存在的键保持原来的位置
+func (m *OrderedMap[V]) Set(key string, value V) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// Get 返回键对应的值
+func (m *OrderedMap[V]) Get(key string) (V, bool) {
+	value, ok := m.values[key]
+	return value, ok
+}
+
+// Keys 按插入顺序返回所有键
+func (m *OrderedMap[V]) Keys() []string {
+	return m.keys
+}
+
+// Len 返回键的数量
+func (m *OrderedMap[V]) Len() int {
+	return len(m.keys)
+}
+
+// MarshalYAML 按插入顺序编码为 YAML 映射
+func (m *OrderedMap[V]) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, key := range m.keys {
+		keyNode := &yaml.Node{}
+		keyNode.SetString(key)
+		valueNode := &yaml.Node{}
+		if err := valueNode.Encode(m.values[key]); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, keyNode, valueNode)
+	}
+	return node, nil
+}
+
+// MarshalJSON 按插入顺序编码为 JSON 对象
+func (m *OrderedMap[V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		keyData, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		valueData, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(keyData)
+		buf.WriteByte(':')
+		buf.Write(valueData)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
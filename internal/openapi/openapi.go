@@ -0,0 +1,231 @@
+package openapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/YuukiKazuto/kratosgin/internal/parser"
+)
+
+// builder 根据模板生成 OpenAPI 文档
+type builder struct {
+	template *parser.GinTemplate
+	types    map[string]parser.Type
+	enums    map[string]parser.Enum
+}
+
+// Build 根据 gin 模板生成 OpenAPI 3.1 文档
+// 枚举和类型定义生成 components 中的 schema，每条路由生成一个接口，tag 由服务名和分组名组成
+func Build(template *parser.GinTemplate) *Document {
+	b := &builder{template: template, types: make(map[string]parser.Type), enums: make(map[string]parser.Enum)}
+	for _, t := range template.Types {
+		if _, ok := b.types[t.Name]; !ok {
+			b.types[t.Name] = t
+		}
+	}
+	for _, e := range template.Enums {
+		if _, ok := b.enums[e.Name]; !ok {
+			b.enums[e.Name] = e
+		}
+	}
+
+	doc := &Document{
+		OpenAPI: Version,
+		Info: Info{
+			Title:       template.Info.Title,
+			Version:     template.Info.Version,
+			Description: template.Info.Desc,
+		},
+		Paths: NewOrderedMap[*PathItem](),
+	}
+	if doc.Info.Title == "" {
+		doc.Info.Title = "API"
+	}
+	if doc.Info.Version == "" {
+		doc.Info.Version = "1.0.0"
+	}
+
+	tags := make(map[string]bool)
+	for _, route := range template.Routes() {
+		operation := b.operation(route)
+		for _, tag := range operation.Tags {
+			if !tags[tag] {
+				tags[tag] = true
+				doc.Tags = append(doc.Tags, Tag{Name: tag})
+			}
+		}
+
+		path := openAPIPath(route.FullPath)
+		item, ok := doc.Paths.Get(path)
+		if !ok {
+			item = NewOrderedMap[*Operation]()
+			doc.Paths.Set(path, item)
+		}
+		item.Set(strings.ToLower(route.HTTPMethod), operation)
+	}
+
+	types := append([]parser.Type(nil), template.Types...)
+	if template.Options.StandardEnvelope() {
+		// 内置的响应包装类型不在 .gin 文件中定义
+		envelope, _ := template.EnvelopeType()
+		types = append(types, envelope)
+	}
+	if len(types) > 0 || len(template.Enums) > 0 {
+		schemas := NewOrderedMap[*Schema]()
+		for _, e := range template.Enums {
+			if _, ok := schemas.Get(e.Name); !ok {
+				schemas.Set(e.Name, b.enumSchema(e))
+			}
+		}
+		for _, t := range types {
+			if _, ok := schemas.Get(t.Name); !ok {
+				schemas.Set(t.Name, b.typeSchema(t))
+			}
+		}
+		doc.Components = &Components{Schemas: schemas}
+	}
+	return doc
+}
+
+// operation 生成一条路由对应的接口
+func (b *builder) operation(route parser.Route) *Operation {
+	operation := &Operation{
+		Tags:        []string{strings.Join(append([]string{route.Service}, route.Groups...), "/")},
+		Description: route.Description,
+		OperationID: route.Service + "_" + strings.Title(route.Name),
+		Responses:   NewOrderedMap[*Response](),
+	}
+
+	fields := b.template.RequestFields(route.Request)
+	binding := b.template.RequestBinding(route)
+
+	// 路径参数匹配 uri 标签或 json 名称相同的字段，与生成的处理器绑定规则一致
+	pathParams := make(map[string]bool)
+	for _, name := range parser.PathParams(route.FullPath) {
+		pathParams[name] = true
+		parameter := &Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}}
+		if field, ok := parser.PathParamField(fields, name); ok {
+			parameter.Schema = b.fieldSchema(field)
+			parameter.Description = field.Comment
+		}
+		operation.Parameters = append(operation.Parameters, parameter)
+	}
+
+	// GET 请求的其余字段按 form 标签（没有时为字段名）作为查询参数，其他方法只有带 form 标签的字段是查询参数
+	for _, field := range fields {
+		if pathParams[field.TagName("uri")] || pathParams[field.JSONName()] {
+			continue
+		}
+		if name := field.TagName("header"); name != "" && name != "-" {
+			operation.Parameters = append(operation.Parameters, &Parameter{
+				Name:        name,
+				In:          "header",
+				Description: field.Comment,
+				Required:    field.Required,
+				Schema:      b.fieldSchema(field),
+			})
+			continue
+		}
+		if !binding.Query {
+			continue
+		}
+		name := field.TagName("form")
+		if name == "-" || (binding.Body && name == "") {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		operation.Parameters = append(operation.Parameters, &Parameter{
+			Name:        name,
+			In:          "query",
+			Description: field.Comment,
+			Required:    field.Required && !binding.Body,
+			Schema:      b.fieldSchema(field),
+		})
+	}
+
+	if binding.Body && route.HasRequest() {
+		operation.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{"application/json": {Schema: schemaRef(route.Request)}},
+		}
+	}
+
+	// 没有响应类型或状态码为 204 时没有响应体
+	response := &Response{Description: "成功"}
+	if schema := b.responseSchema(route); schema != nil && route.StatusCode() != http.StatusNoContent {
+		response.Content = map[string]*MediaType{"application/json": {Schema: schema}}
+	}
+	operation.Responses.Set(strconv.Itoa(route.StatusCode()), response)
+
+	// 使用响应包装时绑定错误和服务错误同样被包装
+	if route.HasRequest() {
+		operation.Responses.Set("400", &Response{
+			Description: "请求参数错误",
+			Content:     map[string]*MediaType{"application/json": {Schema: b.bindErrorSchema()}},
+		})
+	}
+	if envelope, ok := b.template.EnvelopeType(); ok {
+		operation.Responses.Set("default", &Response{
+			Description: "错误",
+			Content:     map[string]*MediaType{"application/json": {Schema: schemaRef(envelope.Name)}},
+		})
+	}
+	return operation
+}
+
+// responseSchema 返回成功响应的 schema，使用响应包装时通过 allOf 将包装中的数据字段替换为响应类型
+func (b *builder) responseSchema(route parser.Route) *Schema {
+	envelope, ok := b.template.EnvelopeType()
+	if !ok {
+		if !route.HasResponse() {
+			return nil
+		}
+		return schemaRef(route.Response)
+	}
+	fields := envelope.EnvelopeDataFields()
+	if !route.HasResponse() || len(fields) == 0 {
+		return schemaRef(envelope.Name)
+	}
+	name := fields[0].JSONName()
+	data := &Schema{Type: "object", Properties: NewOrderedMap[*Schema]()}
+	data.Properties.Set(name, schemaRef(route.Response))
+	return &Schema{AllOf: []*Schema{schemaRef(envelope.Name), data}}
+}
+
+// bindErrorSchema 返回绑定错误的 schema，校验失败的字段错误以 json 名称为键
+// 不使用响应包装时为 {message, fields}，使用时字段错误放在包装的数据字段中
+func (b *builder) bindErrorSchema() *Schema {
+	fields := &Schema{
+		Type:                 "object",
+		Description:          "校验失败的字段及错误信息，键为字段的 json 名称",
+		AdditionalProperties: &Schema{Type: "string"},
+	}
+	envelope, ok := b.template.EnvelopeType()
+	if !ok {
+		schema := &Schema{Type: "object", Properties: NewOrderedMap[*Schema](), Required: []string{"message"}}
+		schema.Properties.Set("message", &Schema{Type: "string"})
+		schema.Properties.Set("fields", fields)
+		return schema
+	}
+	dataFields := envelope.EnvelopeDataFields()
+	if len(dataFields) == 0 {
+		return schemaRef(envelope.Name)
+	}
+	data := &Schema{Type: "object", Properties: NewOrderedMap[*Schema]()}
+	data.Properties.Set(dataFields[0].JSONName(), fields)
+	return &Schema{AllOf: []*Schema{schemaRef(envelope.Name), data}}
+}
+
+// openAPIPath 将 gin 路径参数 :id 和 *path 转换为 OpenAPI 的 {id} 和 {path}
+func openAPIPath(ginPath string) string {
+	segments := strings.Split(ginPath, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,248 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/YuukiKazuto/kratosgin/internal/parser"
+)
+
+// buildSource 解析 options 块加 source 的源码并生成 OpenAPI 文档，解析出错时测试失败
+func buildSource(t *testing.T, options, source string) *Document {
+	t.Helper()
+	file, diags := parser.ParseFile("test.gin", "options {\n\tpackageName: v1\n"+options+"}\n"+source)
+	if diags.HasErrors() {
+		t.Fatalf("解析失败:\n%v", diags)
+	}
+	return Build(parser.BuildTemplate(file))
+}
+
+// findOperation 返回文档中指定路径和方法的接口
+func findOperation(t *testing.T, doc *Document, path, method string) *Operation {
+	t.Helper()
+	item, ok := doc.Paths.Get(path)
+	if !ok {
+		t.Fatalf("文档中没有路径 %s，已有 %q", path, doc.Paths.Keys())
+	}
+	operation, ok := item.Get(method)
+	if !ok {
+		t.Fatalf("路径 %s 中没有 %s 方法", path, method)
+	}
+	return operation
+}
+
+// paramStrings 以 "位置 名称 类型" 的格式列出参数，必填参数带 * 后缀
+func paramStrings(operation *Operation) []string {
+	var list []string
+	for _, p := range operation.Parameters {
+		s := fmt.Sprintf("%s %s %s", p.In, p.Name, p.Schema.Type)
+		if p.Required {
+			s += "*"
+		}
+		list = append(list, s)
+	}
+	return list
+}
+
+func TestOperationParameters(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		path   string
+		method string
+		want   []string
+		body   bool // 是否有请求体
+	}{
+		{
+			name: "GET 请求的路径、查询和请求头参数",
+			source: `type (
+	GetReq {
+		ID int64 ` + "`uri:\"id\" binding:\"required\"`" + `
+		Token string ` + "`header:\"X-Token\" binding:\"required\"`" + `
+		Page int ` + "`form:\"page\"`" + `
+		Keyword string
+		Hidden string ` + "`form:\"-\"`" + `
+	}
+	Resp {}
+)
+@Get GET /items/:id GetReq Resp
+`,
+			path:   "/items/{id}",
+			method: "get",
+			want:   []string{"path id integer*", "header X-Token string*", "query page integer", "query Keyword string"},
+		},
+		{
+			name: "POST 请求只有带 form 标签的字段是查询参数",
+			source: `type (
+	CreateReq {
+		Token string ` + "`json:\"-\" header:\"X-Token\"`" + `
+		DryRun bool ` + "`form:\"dry_run\" binding:\"required\"`" + `
+		Name string ` + "`json:\"name\" binding:\"required\"`" + `
+	}
+	Resp {}
+)
+@Create POST /items CreateReq Resp
+`,
+			path:   "/items",
+			method: "post",
+			want:   []string{"header X-Token string", "query dry_run boolean"},
+			body:   true,
+		},
+		{
+			name: "路径参数匹配 json 名称，通配符参数",
+			source: `type (
+	FileReq {
+		Bucket string ` + "`json:\"bucket\"`" + `
+	}
+	Resp {}
+)
+@GetFile GET /buckets/:bucket/*path FileReq Resp
+`,
+			path:   "/buckets/{bucket}/{path}",
+			method: "get",
+			want:   []string{"path bucket string*", "path path string*"},
+		},
+		{
+			name: "服务前缀和分组路径",
+			source: `type (
+	Req {
+		ID int ` + "`uri:\"id\"`" + `
+	}
+	Resp {}
+)
+service S prefix v1 {
+	group /admin {
+		@Delete DELETE /items/:id Req -
+	}
+}
+`,
+			path:   "/v1/admin/items/{id}",
+			method: "delete",
+			want:   []string{"path id integer*"},
+			body:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operation := findOperation(t, buildSource(t, "", tt.source), tt.path, tt.method)
+			if got := paramStrings(operation); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("参数 = %q，期望 %q", got, tt.want)
+			}
+			if body := operation.RequestBody != nil; body != tt.body {
+				t.Errorf("请求体 = %v，期望 %v", body, tt.body)
+			}
+		})
+	}
+}
+
+// responseSchemas 以 "状态码 schema 的 JSON" 的格式列出响应，没有响应体时只有状态码
+func responseSchemas(t *testing.T, operation *Operation) []string {
+	t.Helper()
+	var list []string
+	for _, code := range operation.Responses.Keys() {
+		response, _ := operation.Responses.Get(code)
+		media, ok := response.Content["application/json"]
+		if !ok {
+			list = append(list, code)
+			continue
+		}
+		schema, err := json.Marshal(media.Schema)
+		if err != nil {
+			t.Fatal(err)
+		}
+		list = append(list, code+" "+string(schema))
+	}
+	return list
+}
+
+func TestEnvelopeResponses(t *testing.T) {
+	const types = `type (
+	Req {
+		ID int ` + "`uri:\"id\"`" + `
+	}
+	Resp {}
+	Result {
+		Code int ` + "`json:\"code\"`" + `
+		Payload interface{} ` + "`json:\"payload\"`" + `
+	}
+)
+`
+	const bindError = `{"type":"object","description":"校验失败的字段及错误信息，键为字段的 json 名称","additionalProperties":{"type":"string"}}`
+
+	tests := []struct {
+		name     string
+		envelope string
+		method   string
+		want     []string
+	}{
+		{
+			name:   "不使用响应包装",
+			method: "@Get GET /items/:id Req Resp",
+			want: []string{
+				`200 {"$ref":"#/components/schemas/Resp"}`,
+				`400 {"type":"object","properties":{"message":{"type":"string"},"fields":` + bindError + `},"required":["message"]}`,
+			},
+		},
+		{
+			name:     "标准响应包装",
+			envelope: "standard",
+			method:   "@Get GET /items/:id Req Resp",
+			want: []string{
+				`200 {"allOf":[{"$ref":"#/components/schemas/Envelope"},{"type":"object","properties":{"data":{"$ref":"#/components/schemas/Resp"}}}]}`,
+				`400 {"allOf":[{"$ref":"#/components/schemas/Envelope"},{"type":"object","properties":{"data":` + bindError + `}}]}`,
+				`default {"$ref":"#/components/schemas/Envelope"}`,
+			},
+		},
+		{
+			name:     "自定义响应包装的数据字段",
+			envelope: "Result",
+			method:   "@Get GET /items/:id Req Resp status:201",
+			want: []string{
+				`201 {"allOf":[{"$ref":"#/components/schemas/Result"},{"type":"object","properties":{"payload":{"$ref":"#/components/schemas/Resp"}}}]}`,
+				`400 {"allOf":[{"$ref":"#/components/schemas/Result"},{"type":"object","properties":{"payload":` + bindError + `}}]}`,
+				`default {"$ref":"#/components/schemas/Result"}`,
+			},
+		},
+		{
+			name:     "没有响应类型时返回包装类型",
+			envelope: "standard",
+			method:   "@Ping GET /ping - -",
+			want: []string{
+				`200 {"$ref":"#/components/schemas/Envelope"}`,
+				`default {"$ref":"#/components/schemas/Envelope"}`,
+			},
+		},
+		{
+			name:     "204 没有响应体",
+			envelope: "standard",
+			method:   "@Delete DELETE /items/:id Req - status:204",
+			want: []string{
+				`204`,
+				`400 {"allOf":[{"$ref":"#/components/schemas/Envelope"},{"type":"object","properties":{"data":` + bindError + `}}]}`,
+				`default {"$ref":"#/components/schemas/Envelope"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := ""
+			if tt.envelope != "" {
+				options = "\tenvelope: " + tt.envelope + "\n"
+			}
+			doc := buildSource(t, options, types+tt.method+"\n")
+			var operation *Operation
+			for _, path := range doc.Paths.Keys() {
+				item, _ := doc.Paths.Get(path)
+				for _, method := range item.Keys() {
+					operation, _ = item.Get(method)
+				}
+			}
+			if got := responseSchemas(t, operation); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("响应 =\n%s\n期望\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/YuukiKazuto/kratosgin/internal/parser"
+)
+
+// schemaRef 返回指向 components 中类型的引用
+func schemaRef(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// typeSchema 生成类型定义的 schema，嵌入字段通过 allOf 合并
+func (b *builder) typeSchema(t parser.Type) *Schema {
+	if t.IsAlias {
+		schema := b.goTypeSchema(t.AliasTo)
+		schema.Description = t.Comment
+		return schema
+	}
+
+	object := &Schema{Type: "object", Properties: NewOrderedMap[*Schema]()}
+	var embedded []*Schema
+	for _, field := range t.Fields {
+		name := jsonName(field)
+		if name == "-" {
+			continue
+		}
+		// 没有 json 名称的嵌入字段，其字段被提升到外层
+		if field.Name == "" && name == "" {
+			embedded = append(embedded, b.goTypeSchema(field.Type))
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		object.Properties.Set(name, b.fieldSchema(field))
+		if field.Required {
+			object.Required = append(object.Required, name)
+		}
+	}
+
+	if len(embedded) == 0 {
+		object.Description = t.Comment
+		return object
+	}
+	schema := &Schema{Description: t.Comment, AllOf: embedded}
+	if object.Properties.Len() > 0 {
+		schema.AllOf = append(schema.AllOf, object)
+	}
+	return schema
+}
+
+// enumSchema 生成枚举的 schema，描述中列出每个取值的名称和注释
+func (b *builder) enumSchema(e parser.Enum) *Schema {
+	schema := b.goTypeSchema(e.Type)
+	var lines []string
+	if e.Comment != "" {
+		lines = append(lines, e.Comment, "")
+	}
+	for _, v := range e.Values {
+		schema.Enum = append(schema.Enum, enumValue(v))
+		schema.EnumVarNames = append(schema.EnumVarNames, v.Name)
+		line := fmt.Sprintf("- %s: %s", v.Literal(), v.Name)
+		if v.Comment != "" {
+			line += " " + v.Comment
+		}
+		lines = append(lines, line)
+	}
+	schema.Description = strings.Join(lines, "\n")
+	return schema
+}
+
+// enumValue 返回枚举取值在文档中的值，整数取值保持整数
+func enumValue(v parser.EnumValue) interface{} {
+	if v.IsString {
+		return v.Value
+	}
+	if n, err := strconv.ParseInt(v.Value, 10, 64); err == nil {
+		return n
+	}
+	if n, err := strconv.ParseUint(v.Value, 10, 64); err == nil {
+		return n
+	}
+	return v.Value
+}
+
+// fieldSchema 生成字段的 schema，并根据 binding 规则添加约束
+func (b *builder) fieldSchema(field parser.Field) *Schema {
+	schema := b.goTypeSchema(field.Type)
+	schema.Description = field.Comment
+	applyBinding(schema, reflect.StructTag(field.Tag).Get("binding"))
+	return schema
+}
+
+// goTypeSchema 将 Go 类型表达式映射为 schema
+func (b *builder) goTypeSchema(goType string) *Schema {
+	goType = strings.TrimPrefix(strings.TrimSpace(goType), "*")
+
+	switch {
+	case goType == "[]byte":
+		return &Schema{Type: "string", Format: "byte"}
+	case strings.HasPrefix(goType, "[]"):
+		return &Schema{Type: "array", Items: b.goTypeSchema(goType[2:])}
+	case strings.HasPrefix(goType, "["):
+		// 固定长度数组 [N]T
+		if end := strings.Index(goType, "]"); end > 0 {
+			return &Schema{Type: "array", Items: b.goTypeSchema(goType[end+1:])}
+		}
+	case strings.HasPrefix(goType, "map["):
+		if end := matchingBracket(goType, len("map")); end > 0 {
+			return &Schema{Type: "object", AdditionalProperties: b.goTypeSchema(goType[end+1:])}
+		}
+	}
+
+	switch goType {
+	case "string":
+		return &Schema{Type: "string"}
+	case "bool":
+		return &Schema{Type: "boolean"}
+	case "int", "int8", "int16", "uint", "uint8", "uint16", "uint32", "byte":
+		return &Schema{Type: "integer"}
+	case "int32", "rune":
+		return &Schema{Type: "integer", Format: "int32"}
+	case "int64", "uint64", "time.Duration":
+		return &Schema{Type: "integer", Format: "int64"}
+	case "float32":
+		return &Schema{Type: "number", Format: "float"}
+	case "float64":
+		return &Schema{Type: "number", Format: "double"}
+	case "time.Time":
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	if _, ok := b.types[goType]; ok {
+		return schemaRef(goType)
+	}
+	if _, ok := b.enums[goType]; ok {
+		return schemaRef(goType)
+	}
+	// interface{}、any 以及外部包的类型不限制
+	return &Schema{}
+}
+
+// matchingBracket 返回 s[open] 处的 [ 对应的 ] 的位置
+func matchingBracket(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// applyBinding 将 validator 的 binding 规则转换为 schema 约束
+// dive 之后的规则作用于数组元素或 map 的值
+func applyBinding(schema *Schema, binding string) {
+	target := schema
+	for _, rule := range strings.Split(binding, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		switch name {
+		case "dive":
+			switch {
+			case target.Items != nil:
+				target = target.Items
+			case target.AdditionalProperties != nil:
+				target = target.AdditionalProperties
+			default:
+				return
+			}
+		case "email":
+			target.Format = "email"
+		case "url", "http_url", "uri":
+			target.Format = "uri"
+		case "uuid", "uuid4":
+			target.Format = "uuid"
+		case "ipv4":
+			target.Format = "ipv4"
+		case "ipv6":
+			target.Format = "ipv6"
+		case "datetime":
+			target.Format = "date-time"
+		case "min", "gte":
+			setBound(target, param, true, false)
+		case "max", "lte":
+			setBound(target, param, false, false)
+		case "gt":
+			setBound(target, param, true, true)
+		case "lt":
+			setBound(target, param, false, true)
+		case "len":
+			setBound(target, param, true, false)
+			setBound(target, param, false, false)
+		case "oneof":
+			// 枚举类型的取值已在引用的 schema 中列出
+			if target.Ref != "" {
+				continue
+			}
+			target.Enum = enumValues(target.Type, strings.Fields(param))
+		}
+	}
+}
+
+// setBound 根据 schema 的类型设置长度、元素个数或数值范围
+func setBound(schema *Schema, param string, lower, exclusive bool) {
+	value, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	switch schema.Type {
+	case "string":
+		if exclusive {
+			return
+		}
+		n := int(value)
+		if lower {
+			schema.MinLength = &n
+		} else {
+			schema.MaxLength = &n
+		}
+	case "array":
+		if exclusive {
+			return
+		}
+		n := int(value)
+		if lower {
+			schema.MinItems = &n
+		} else {
+			schema.MaxItems = &n
+		}
+	case "integer", "number":
+		switch {
+		case lower && exclusive:
+			schema.ExclusiveMinimum = &value
+		case lower:
+			schema.Minimum = &value
+		case exclusive:
+			schema.ExclusiveMaximum = &value
+		default:
+			schema.Maximum = &value
+		}
+	}
+}
+
+// enumValues 将 oneof 的参数按 schema 类型转换为枚举值
+func enumValues(schemaType string, params []string) []interface{} {
+	values := make([]interface{}, 0, len(params))
+	for _, param := range params {
+		param = strings.Trim(param, "'")
+		if schemaType == "integer" || schemaType == "number" {
+			if n, err := strconv.ParseFloat(param, 64); err == nil {
+				values = append(values, n)
+				continue
+			}
+		}
+		values = append(values, param)
+	}
+	return values
+}
+
+// jsonName 返回字段 json 标签中的名称，没有 json 标签时返回空字符串
+func jsonName(field parser.Field) string {
+	return field.TagName("json")
+}
@@ -0,0 +1,172 @@
+package parser
+
+import "fmt"
+
+// Pos 表示源码中的位置
+type Pos struct {
+	File   string // 文件名，可能为空
+	Line   int    // 行号，从 1 开始
+	Column int    // 列号（按字符计），从 1 开始
+}
+
+// IsValid 判断位置是否有效
+func (p Pos) IsValid() bool {
+	return p.Line > 0
+}
+
+// String 返回 file:line:column 格式的位置
+func (p Pos) String() string {
+	if !p.IsValid() {
+		if p.File != "" {
+			return p.File
+		}
+		return "-"
+	}
+	if p.File == "" {
+		return fmt.Sprintf("%d:%d", p.Line, p.Column)
+	}
+	return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+}
+
+// File 表示一个 .gin 文件的语法树
+type File struct {
+	Name       string
+	Imports    []*ImportSpec
+	Info       *KeyValueBlock
+	Options    *KeyValueBlock
+	Types      []*TypeSpec
+	Services   []*ServiceDecl
+	Groups     []*GroupDecl     // 顶级路由分组
+	Routes     []*MethodDecl    // 独立路由
+	Validators []*ValidatorDecl // validators 块中的自定义校验规则
+	Enums      []*EnumDecl
+}
+
+// ImportSpec 表示一条 import，Path 为源码中的原始路径
+type ImportSpec struct {
+	Pos  Pos
+	Path string
+}
+
+// KeyValueBlock 表示 info / options 块
+type KeyValueBlock struct {
+	Pos     Pos
+	Entries []*KeyValue
+}
+
+// KeyValue 表示块中的 key: value 项
+type KeyValue struct {
+	Pos      Pos
+	Key      string
+	Value    string
+	ValuePos Pos
+}
+
+// TypeSpec 表示一个类型定义，Pos 指向类型名
+type TypeSpec struct {
+	Pos     Pos
+	Name    string
+	Doc     string // 类型上方的注释
+	IsAlias bool
+	AliasTo string
+	Fields  []*FieldDecl
+}
+
+// FieldDecl 表示结构体字段，嵌入字段的 Name 为空
+type FieldDecl struct {
+	Pos     Pos
+	Name    string
+	Type    string
+	TypePos Pos
+	Tag     string
+	TagPos  Pos
+	Comment string
+}
+
+// ServiceDecl 表示服务定义，Pos 指向服务名
+type ServiceDecl struct {
+	Pos        Pos
+	Name       string
+	Prefix     string
+	Doc        string
+	Middleware []*MiddlewareEntry
+	Methods    []*MethodDecl
+	Groups     []*GroupDecl
+}
+
+// GroupDecl 表示路由分组，Pos 指向 group 关键字
+type GroupDecl struct {
+	Pos        Pos
+	Name       string
+	Path       string
+	PathPos    Pos
+	Doc        string
+	Middleware []*MiddlewareEntry
+	Methods    []*MethodDecl
+	Groups     []*GroupDecl // 嵌套的子分组
+}
+
+// MiddlewareEntry 表示中间件列表中的一项
+type MiddlewareEntry struct {
+	Pos  Pos
+	Name string
+	Skip bool // 来自 skip: [...] 或 "-name"，表示不使用上级的该中间件
+}
+
+// MethodDecl 表示方法（路由）定义，Pos 指向 @
+type MethodDecl struct {
+	Pos            Pos
+	Name           string
+	HTTPMethod     string
+	Path           string
+	PathPos        Pos
+	Request        string
+	RequestPos     Pos
+	Response       string
+	ResponsePos    Pos
+	Status         int // status:201 指定的成功状态码，未指定时为 0
+	StatusPos      Pos
+	WithGinContext bool
+	Middleware     []*MiddlewareEntry
+	Doc            string // 方法上方的注释
+	Comment        string // 方法行尾的注释
+}
+
+// ValidatorDecl 表示 validators 块中的一条自定义校验规则，Pos 指向规则名
+type ValidatorDecl struct {
+	Pos        Pos
+	Name       string
+	Kind       string // 规则类型，如 regex
+	KindPos    Pos
+	Pattern    string
+	PatternPos Pos
+	Messages   []ValidatorMessage
+	Comment    string
+}
+
+// ValidatorMessage 表示校验规则某种语言的错误信息，如 zh: "{0}必须是有效的手机号"
+type ValidatorMessage struct {
+	Pos    Pos
+	Locale string
+	Text   string
+}
+
+// EnumDecl 表示枚举定义，Pos 指向枚举名
+type EnumDecl struct {
+	Pos     Pos
+	Name    string
+	Type    string // 基础类型，整数类型或 string
+	TypePos Pos
+	Doc     string
+	Values  []*EnumValueDecl
+}
+
+// EnumValueDecl 表示枚举的一个取值，Pos 指向取值名
+type EnumValueDecl struct {
+	Pos      Pos
+	Name     string
+	Value    string // 整数字面量或解码后的字符串
+	ValuePos Pos
+	IsString bool // 取值是否为字符串字面量
+	Comment  string
+}
@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// Binding 描述处理器按什么顺序绑定请求：路径参数、查询参数、请求头、请求体
+type Binding struct {
+	URI     bool     // 路径中有参数，绑定 uri 标签或 json 名称相同的字段
+	Query   bool     // 绑定 form 标签对应的查询参数
+	Headers []string // header 标签中的请求头名称
+	Body    bool     // 按 Content-Type 绑定请求体，GET 和 HEAD 请求没有请求体
+}
+
+// HasBody 判断该 HTTP 方法的请求是否绑定请求体
+func HasBody(httpMethod string) bool {
+	method := strings.ToUpper(httpMethod)
+	return method != http.MethodGet && method != http.MethodHead
+}
+
+// RequestBinding 返回路由的请求绑定方式
+// GET 和 HEAD 请求的全部字段都可以来自查询参数；其他方法只有带 form 标签的字段从查询参数绑定
+func (t *GinTemplate) RequestBinding(route Route) Binding {
+	fields := t.RequestFields(route.Request)
+	binding := Binding{
+		URI:  len(PathParams(route.FullPath)) > 0,
+		Body: HasBody(route.HTTPMethod),
+	}
+	binding.Query = !binding.Body
+	seen := make(map[string]bool)
+	for _, field := range fields {
+		if name := field.TagName("form"); name != "" && name != "-" {
+			binding.Query = true
+		}
+		if name := field.TagName("header"); name != "" && name != "-" && !seen[name] {
+			seen[name] = true
+			binding.Headers = append(binding.Headers, name)
+		}
+	}
+	return binding
+}
+
+// RequestFields 返回请求类型的全部字段，展开类型别名和没有 json 名称的嵌入字段
+func (t *GinTemplate) RequestFields(name string) []Field {
+	types := make(map[string]Type, len(t.Types))
+	for _, typ := range t.Types {
+		if _, ok := types[typ.Name]; !ok {
+			types[typ.Name] = typ
+		}
+	}
+
+	var fields []Field
+	seen := make(map[string]bool)
+	var collect func(name string)
+	collect = func(name string) {
+		name = strings.TrimPrefix(strings.TrimSpace(name), "*")
+		typ, ok := types[name]
+		if !ok || seen[name] {
+			return
+		}
+		seen[name] = true
+		if typ.IsAlias {
+			collect(typ.AliasTo)
+			return
+		}
+		for _, field := range typ.Fields {
+			if field.Name == "" && field.TagName("json") == "" {
+				collect(field.Type)
+				continue
+			}
+			if field.Name != "" {
+				fields = append(fields, field)
+			}
+		}
+	}
+	collect(name)
+	return fields
+}
+
+// TagName 返回字段指定标签中的名称，不包含逗号后的选项
+func (f Field) TagName(key string) string {
+	name, _, _ := strings.Cut(reflect.StructTag(f.Tag).Get(key), ",")
+	return name
+}
+
+// JSONName 返回字段 json 标签中的名称，没有 json 标签时为字段名
+func (f Field) JSONName() string {
+	if name := f.TagName("json"); name != "" {
+		return name
+	}
+	return f.Name
+}
+
+// PathParamField 返回与路径参数匹配的字段：uri 标签与参数名相同，或者 json 名称与参数名相同
+func PathParamField(fields []Field, param string) (Field, bool) {
+	for _, field := range fields {
+		if field.TagName("uri") == param || field.JSONName() == param {
+			return field, true
+		}
+	}
+	return Field{}, false
+}
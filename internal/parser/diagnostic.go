@@ -0,0 +1,222 @@
+package parser
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Severity 表示诊断信息的级别
+type Severity int
+
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+)
+
+// String 返回级别名称
+func (s Severity) String() string {
+	if s == SeverityWarning {
+		return "warning"
+	}
+	return "error"
+}
+
+// 诊断代码，一经发布不再变更含义
+const (
+	CodeIllegalChar       = "E0001" // 非法字符
+	CodeUnterminated      = "E0002" // 字符串或反引号未闭合
+	CodeUnexpectedToken   = "E0003" // 语法错误
+	CodeUnknownHTTPMethod = "E0004" // 不支持的 HTTP 方法
+	CodeAnonymousStruct   = "E0005" // 匿名结构体
+	CodeNestedGroup       = "E0006" // 嵌套路由组（已支持嵌套，不再使用）
+	CodeInvalidString     = "E0007" // 无效的字符串字面量
+	CodeImportCycle       = "E0008" // 循环导入
+	CodeImportNotFound    = "E0009" // 导入的文件不存在
+	CodeInvalidStatus     = "E0010" // 无效的成功状态码
+
+	CodeDuplicateBlock  = "W0001" // 重复的 info / options 块
+	CodeUnknownKey      = "W0002" // 未知的配置项
+	CodeImportedIgnored = "W0003" // 导入文件中被忽略的声明
+	CodeUselessSkip     = "W0004" // 服务级中间件中的 skip
+)
+
+// defaultHints 各诊断代码的默认提示
+var defaultHints = map[string]string{
+	CodeIllegalChar:       "删除该字符，或将其放入字符串或注释中",
+	CodeUnterminated:      "补全结束的引号或反引号",
+	CodeInvalidString:     "检查字符串中的转义序列",
+	CodeUnknownHTTPMethod: "可用的方法: GET、POST、PUT、DELETE、PATCH、HEAD、OPTIONS",
+	CodeAnonymousStruct:   "先单独定义该结构体类型，再在字段中引用它",
+	CodeDuplicateBlock:    "合并为一个块",
+	CodeImportedIgnored:   "导入的文件只合并类型定义和校验规则，服务和路由请在当前文件中定义",
+	CodeUselessSkip:       "skip 和 \"-name\" 只能用于分组和方法",
+}
+
+// Diagnostic 表示一条带位置的错误或警告
+type Diagnostic struct {
+	Pos      Pos
+	Severity Severity
+	Code     string
+	Message  string
+	Hint     string
+}
+
+// Error 实现 error 接口
+func (d *Diagnostic) Error() string {
+	return fmt.Sprintf("%s: %s[%s]: %s", d.Pos, d.Severity, d.Code, d.Message)
+}
+
+// Diagnostics 诊断信息列表
+type Diagnostics []*Diagnostic
+
+// Error 实现 error 接口，逐行列出所有诊断
+func (ds Diagnostics) Error() string {
+	lines := make([]string, 0, len(ds))
+	for _, d := range ds {
+		lines = append(lines, d.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// HasErrors 判断是否包含错误级别的诊断
+func (ds Diagnostics) HasErrors() bool {
+	for _, d := range ds {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Err 存在错误时返回自身，否则返回 nil
+func (ds Diagnostics) Err() error {
+	if ds.HasErrors() {
+		return ds
+	}
+	return nil
+}
+
+// Sort 按文件、行、列排序
+func (ds Diagnostics) Sort() {
+	sort.SliceStable(ds, func(i, j int) bool {
+		a, b := ds[i].Pos, ds[j].Pos
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+}
+
+// Errorf 添加一条错误
+func (ds *Diagnostics) Errorf(pos Pos, code, format string, args ...interface{}) *Diagnostic {
+	return ds.add(pos, SeverityError, code, format, args...)
+}
+
+// Warnf 添加一条警告
+func (ds *Diagnostics) Warnf(pos Pos, code, format string, args ...interface{}) *Diagnostic {
+	return ds.add(pos, SeverityWarning, code, format, args...)
+}
+
+func (ds *Diagnostics) add(pos Pos, severity Severity, code, format string, args ...interface{}) *Diagnostic {
+	d := newDiagnostic(pos, severity, code, format, args...)
+	*ds = append(*ds, d)
+	return d
+}
+
+func newDiagnostic(pos Pos, severity Severity, code, format string, args ...interface{}) *Diagnostic {
+	return &Diagnostic{
+		Pos:      pos,
+		Severity: severity,
+		Code:     code,
+		Message:  fmt.Sprintf(format, args...),
+		Hint:     defaultHints[code],
+	}
+}
+
+// WithHint 设置提示信息并返回自身
+func (d *Diagnostic) WithHint(format string, args ...interface{}) *Diagnostic {
+	d.Hint = fmt.Sprintf(format, args...)
+	return d
+}
+
+// WriteDiagnostics 以编译器风格输出诊断信息，sources 中缺少的文件会从磁盘读取
+func WriteDiagnostics(w io.Writer, ds Diagnostics, sources map[string]string) {
+	cache := make(map[string][]string)
+	lines := func(file string) []string {
+		if l, ok := cache[file]; ok {
+			return l
+		}
+		src, ok := sources[file]
+		if !ok && file != "" {
+			if content, err := os.ReadFile(file); err == nil {
+				src = string(content)
+			}
+		}
+		cache[file] = strings.Split(src, "\n")
+		return cache[file]
+	}
+
+	errors, warnings := 0, 0
+	for _, d := range ds {
+		if d.Severity == SeverityError {
+			errors++
+		} else {
+			warnings++
+		}
+
+		fmt.Fprintf(w, "%s: %s[%s]: %s\n", d.Pos, d.Severity, d.Code, d.Message)
+		if d.Pos.IsValid() {
+			if src := lines(d.Pos.File); d.Pos.Line <= len(src) {
+				line := strings.TrimRight(src[d.Pos.Line-1], "\r")
+				gutter := fmt.Sprintf("%d", d.Pos.Line)
+				pad := strings.Repeat(" ", len(gutter))
+				fmt.Fprintf(w, " %s |\n", pad)
+				fmt.Fprintf(w, " %s | %s\n", gutter, line)
+				fmt.Fprintf(w, " %s | %s^\n", pad, caretIndent(line, d.Pos.Column))
+			}
+		}
+		if d.Hint != "" {
+			fmt.Fprintf(w, " = 提示: %s\n", d.Hint)
+		}
+		fmt.Fprintln(w)
+	}
+
+	if errors > 0 || warnings > 0 {
+		fmt.Fprintf(w, "共 %d 个错误，%d 个警告\n", errors, warnings)
+	}
+}
+
+// caretIndent 生成与源码对齐的缩进，保留制表符并按双倍宽度处理中文等宽字符
+func caretIndent(line string, column int) string {
+	var b strings.Builder
+	i := 1
+	for _, r := range line {
+		if i >= column {
+			break
+		}
+		switch {
+		case r == '\t':
+			b.WriteRune('\t')
+		case isWide(r):
+			b.WriteString("  ")
+		default:
+			b.WriteRune(' ')
+		}
+		i++
+	}
+	return b.String()
+}
+
+// isWide 判断字符在终端中是否占两列
+func isWide(r rune) bool {
+	return unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hangul, r) ||
+		unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r) ||
+		(r >= 0xFF00 && r <= 0xFF60) || (r >= 0x3000 && r <= 0x303F)
+}
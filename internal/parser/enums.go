@@ -0,0 +1,258 @@
+package parser
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Enum 表示枚举定义，生成具名类型、常量、String 方法和 JSON 编解码方法
+type Enum struct {
+	Pos     Pos
+	Name    string
+	Type    string // 基础类型，整数类型或 string
+	TypePos Pos
+	Comment string
+	Values  []EnumValue
+}
+
+// EnumValue 表示枚举的一个取值
+type EnumValue struct {
+	Pos      Pos
+	Name     string
+	Const    string // 生成的常量名，为枚举名加取值名
+	Value    string // 整数字面量或解码后的字符串
+	ValuePos Pos
+	IsString bool
+	Comment  string
+}
+
+// IsString 判断是否为字符串枚举
+func (e Enum) IsString() bool {
+	return e.Type == "string"
+}
+
+// Literal 返回取值在 Go 代码中的字面量
+func (v EnumValue) Literal() string {
+	if v.IsString {
+		return strconv.Quote(v.Value)
+	}
+	return v.Value
+}
+
+// OneOf 返回 binding 标签中 oneof 规则的参数，含空白的字符串取值用单引号包裹
+// validator 按逗号和竖线拆分规则，取值中的逗号和竖线需要写作 0x2C 和 0x7C
+func (e Enum) OneOf() string {
+	params := make([]string, 0, len(e.Values))
+	for _, v := range e.Values {
+		param := v.Value
+		if v.IsString {
+			param = strings.NewReplacer(",", "0x2C", "|", "0x7C").Replace(param)
+			if param == "" || strings.IndexFunc(param, isSpace) >= 0 {
+				param = "'" + param + "'"
+			}
+		}
+		params = append(params, param)
+	}
+	return strings.Join(params, " ")
+}
+
+func isSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == '\v' || r == '\f'
+}
+
+// parseEnum 解析 enum Name int { Active = 1 // 注释 }，取值可以为整数或字符串，以换行或分号分隔
+func (p *parser) parseEnum() (*EnumDecl, error) {
+	p.next() // enum
+	name, err := p.expect(tokIdent, "枚举名")
+	if err != nil {
+		return nil, err
+	}
+	typ, err := p.expect(tokIdent, "枚举的基础类型")
+	if err != nil {
+		return nil, err
+	}
+	decl := &EnumDecl{Pos: name.pos, Name: name.text, Type: typ.text, TypePos: typ.pos, Doc: p.takeDoc()}
+
+	open, err := p.expect(tokLBrace, "'{'")
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipBlank()
+		p.doc = nil
+		switch p.peek().kind {
+		case tokRBrace:
+			p.closeBlock()
+			return decl, nil
+		case tokEOF, tokRParen:
+			p.diags = append(p.diags, p.unclosed(open, "枚举"))
+			return decl, nil
+		}
+		value, err := p.parseEnumValue()
+		if err != nil {
+			p.reportAndSkip(err, hintEnum)
+			continue
+		}
+		decl.Values = append(decl.Values, value)
+	}
+}
+
+// parseEnumValue 解析 Active = 1 // 注释
+func (p *parser) parseEnumValue() (*EnumValueDecl, error) {
+	name, err := p.expect(tokIdent, "枚举取值名或 '}'")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(tokAssign, "'='"); err != nil {
+		return nil, err
+	}
+	value := &EnumValueDecl{Pos: name.pos, Name: name.text, ValuePos: p.peek().pos}
+
+	tok := p.next()
+	switch tok.kind {
+	case tokString:
+		value.Value = tok.value
+		value.IsString = true
+	case tokInt:
+		value.Value = trimLeadingZeros(tok.text)
+	case tokMinus:
+		digits, err := p.expect(tokInt, "整数")
+		if err != nil {
+			return nil, err
+		}
+		value.Value = "-" + trimLeadingZeros(digits.text)
+		if value.Value == "-0" {
+			value.Value = "0"
+		}
+	default:
+		return nil, p.unexpected(tok, "整数或字符串")
+	}
+
+	comment, err := p.endOfLine()
+	if err != nil {
+		return nil, err
+	}
+	value.Comment = comment
+	return value, nil
+}
+
+// trimLeadingZeros 去掉整数的前导零，避免生成的常量被当作八进制
+func trimLeadingZeros(digits string) string {
+	if digits = strings.TrimLeft(digits, "0"); digits == "" {
+		return "0"
+	}
+	return digits
+}
+
+func buildEnum(decl *EnumDecl) Enum {
+	e := Enum{
+		Pos:     decl.Pos,
+		Name:    decl.Name,
+		Type:    decl.Type,
+		TypePos: decl.TypePos,
+		Comment: decl.Doc,
+		Values:  make([]EnumValue, 0, len(decl.Values)),
+	}
+	for _, v := range decl.Values {
+		e.Values = append(e.Values, EnumValue{
+			Pos:      v.Pos,
+			Name:     v.Name,
+			Const:    decl.Name + v.Name,
+			Value:    v.Value,
+			ValuePos: v.ValuePos,
+			IsString: v.IsString,
+			Comment:  v.Comment,
+		})
+	}
+	return e
+}
+
+// EnumType 按名称查找枚举
+func (t *GinTemplate) EnumType(name string) (Enum, bool) {
+	for _, e := range t.Enums {
+		if e.Name == name {
+			return e, true
+		}
+	}
+	return Enum{}, false
+}
+
+var bindingTagPattern = regexp.MustCompile(`(^|\s)binding:"(?:[^"\\]|\\.)*"`)
+
+// applyEnumRules 为使用枚举类型的字段添加 oneof 规则，标签中已有 oneof 或 binding:"-" 时不修改
+// 没有其他规则时加上 omitempty，切片、数组和 map 字段通过 dive 校验其中的元素
+func (t *GinTemplate) applyEnumRules() {
+	if len(t.Enums) == 0 {
+		return
+	}
+	for i := range t.Types {
+		for j := range t.Types[i].Fields {
+			field := &t.Types[i].Fields[j]
+			name, dive := enumFieldType(field.Type)
+			e, ok := t.EnumType(name)
+			if !ok {
+				continue
+			}
+			hasDive := false
+			for _, rule := range field.BindingRules() {
+				if rule == "-" || rule == "oneof" {
+					ok = false
+				}
+				hasDive = hasDive || rule == "dive"
+			}
+			if ok {
+				field.Tag = withEnumRule(field.Tag, e.OneOf(), dive && !hasDive)
+			}
+		}
+	}
+}
+
+// enumFieldType 去掉字段类型的指针、切片和 map 修饰，返回元素类型名以及是否需要 dive，多层嵌套的类型返回空名称
+func enumFieldType(typ string) (string, bool) {
+	dive := false
+	for {
+		switch {
+		case strings.HasPrefix(typ, "*"):
+			typ = typ[1:]
+		case (strings.HasPrefix(typ, "[") || strings.HasPrefix(typ, "map[")) && !dive:
+			end := strings.Index(typ, "]")
+			if end < 0 {
+				return "", false
+			}
+			typ = typ[end+1:]
+			dive = true
+		case strings.HasPrefix(typ, "["), strings.HasPrefix(typ, "map["):
+			return "", false
+		default:
+			return typ, dive
+		}
+	}
+}
+
+func withEnumRule(tag, oneOf string, dive bool) string {
+	rules, ok := reflect.StructTag(tag).Lookup("binding")
+	rule := "oneof=" + oneOf
+	if dive {
+		rule = "dive," + rule
+	}
+	if rules == "" {
+		rules = "omitempty," + rule
+	} else {
+		rules += "," + rule
+	}
+
+	binding := "binding:" + strconv.Quote(rules)
+	if !ok {
+		if tag == "" {
+			return binding
+		}
+		return tag + " " + binding
+	}
+	loc := bindingTagPattern.FindStringSubmatchIndex(tag)
+	if loc == nil {
+		return tag
+	}
+	return tag[:loc[3]] + binding + tag[loc[1]:]
+}
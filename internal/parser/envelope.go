@@ -0,0 +1,70 @@
+package parser
+
+import "strings"
+
+const (
+	// StandardEnvelope envelope: standard 使用内置的响应包装类型
+	StandardEnvelope = "standard"
+	// StandardEnvelopeType 内置响应包装类型的类型名
+	StandardEnvelopeType = "Envelope"
+)
+
+// standardEnvelope 内置的响应包装类型，成功时 code 为 0，失败时为 HTTP 状态码
+var standardEnvelope = Type{
+	Name:    StandardEnvelopeType,
+	Comment: "Envelope 统一的响应结构，成功时 code 为 0，失败时为 HTTP 状态码",
+	Fields: []Field{
+		{Name: "Code", Type: "int", Tag: `json:"code"`, Comment: "业务状态码"},
+		{Name: "Msg", Type: "string", Tag: `json:"msg"`, Comment: "提示信息"},
+		{Name: "Data", Type: "interface{}", Tag: `json:"data,omitempty"`, Comment: "响应数据"},
+	},
+}
+
+// HasEnvelope 判断是否使用响应包装
+func (o Options) HasEnvelope() bool {
+	return o.Envelope != ""
+}
+
+// StandardEnvelope 判断是否使用内置的响应包装类型
+func (o Options) StandardEnvelope() bool {
+	return o.Envelope == StandardEnvelope
+}
+
+// EnvelopeFuncName 返回创建自定义响应包装的构造函数名，未指定时为 New + 类型名
+func (o Options) EnvelopeFuncName() string {
+	if o.EnvelopeFunc != "" {
+		return o.EnvelopeFunc
+	}
+	return "New" + o.Envelope
+}
+
+// EnvelopeType 返回响应包装类型：envelope: standard 时为内置的 Envelope，否则为 .gin 文件中定义的同名类型
+func (t *GinTemplate) EnvelopeType() (Type, bool) {
+	if !t.Options.HasEnvelope() {
+		return Type{}, false
+	}
+	if t.Options.StandardEnvelope() {
+		return standardEnvelope, true
+	}
+	for _, typ := range t.Types {
+		if typ.Name == t.Options.Envelope {
+			return typ, true
+		}
+	}
+	return Type{}, false
+}
+
+// EnvelopeDataFields 返回响应包装类型中存放响应数据的字段，即类型为 any 或 interface{} 的字段
+func (typ Type) EnvelopeDataFields() []Field {
+	var fields []Field
+	for _, field := range typ.Fields {
+		if field.Name == "" {
+			continue
+		}
+		switch strings.ReplaceAll(field.Type, " ", "") {
+		case "any", "interface{}":
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
@@ -1,18 +1,20 @@
 package parser
 
 import (
-	"fmt"
-	"regexp"
+	"net/http"
 	"strings"
 )
 
 // GinTemplate 表示解析后的 gin 模板
 type GinTemplate struct {
+	File             string // .gin 文件名，与解析时传入的相同
 	Info             Info
 	Types            []Type
 	Services         []Service
 	RouteGroups      []RouteGroup
 	StandaloneRoutes []StandaloneRoute
+	Validators       []Validator
+	Enums            []Enum
 	Options          Options
 }
 
@@ -25,6 +27,7 @@ type Info struct {
 
 // Type 表示数据类型定义
 type Type struct {
+	Pos     Pos
 	Name    string
 	Comment string // 类型上方的注释
 	Fields  []Field
@@ -35,15 +38,19 @@ type Type struct {
 
 // Field 表示字段定义
 type Field struct {
+	Pos      Pos
 	Name     string
 	Type     string
+	TypePos  Pos
 	Tag      string
+	TagPos   Pos
 	Comment  string
 	Required bool
 }
 
 // Service 表示服务定义
 type Service struct {
+	Pos         Pos
 	Name        string
 	Prefix      string   // 服务前缀，如 v1, v2 等
 	Middleware  []string // 服务级别中间件
@@ -53,22 +60,102 @@ type Service struct {
 
 // Method 表示方法定义
 type Method struct {
+	Pos            Pos
 	Name           string
 	HTTPMethod     string
 	Path           string
+	PathPos        Pos
 	Request        string
+	RequestPos     Pos
 	Response       string
+	ResponsePos    Pos
+	Status         int // 成功响应的状态码，未指定时为 0，使用 StatusCode 获取实际的状态码
 	Description    string
 	WithGinContext bool     // 是否在 context 中传递 gin.Context
 	Middleware     []string // 中间件列表
+	Skip           []string // 不使用的上级中间件
+}
+
+// EmptyMessage 请求类型或响应类型写作 Empty（或 -）时表示没有请求体或响应体
+const EmptyMessage = "Empty"
+
+// HasRequest 判断方法是否有请求类型，没有时处理器不绑定请求，服务方法也没有 req 参数
+func (m Method) HasRequest() bool {
+	return m.Request != ""
+}
+
+// HasResponse 判断方法是否有响应类型，没有时服务方法只返回 error，处理器只写入状态码
+func (m Method) HasResponse() bool {
+	return m.Response != ""
+}
+
+// StatusCode 返回成功响应的状态码，默认为 200
+func (m Method) StatusCode() int {
+	if m.Status == 0 {
+		return http.StatusOK
+	}
+	return m.Status
 }
 
 // RouteGroup 表示路由分组
 type RouteGroup struct {
+	Pos        Pos
 	Name       string
 	Path       string
 	Middleware []string
+	Skip       []string // 不使用的上级中间件
 	Methods    []Method
+	Groups     []RouteGroup // 嵌套的子分组
+}
+
+// AllMethods 返回分组及其全部子分组中的方法
+func (g RouteGroup) AllMethods() []Method {
+	methods := append([]Method{}, g.Methods...)
+	for _, child := range g.Groups {
+		methods = append(methods, child.AllMethods()...)
+	}
+	return methods
+}
+
+// AllMethods 返回服务中直接定义的方法和各分组中的方法
+func (s Service) AllMethods() []Method {
+	methods := append([]Method{}, s.Methods...)
+	for _, group := range s.RouteGroups {
+		methods = append(methods, group.AllMethods()...)
+	}
+	return methods
+}
+
+// StandaloneServiceName 独立路由的服务接口名
+const StandaloneServiceName = "StandaloneService"
+
+// GroupServiceName 返回顶级分组的服务接口名，例如 admin -> AdminService
+func GroupServiceName(name string) string {
+	return strings.Title(name) + "Service"
+}
+
+// AllServices 返回需要生成服务接口的所有服务：service 块、顶级分组和独立路由
+// 顶级分组和独立路由被视为只有直接方法的服务，分别命名为 <Group>Service 和 StandaloneService
+func (t *GinTemplate) AllServices() []Service {
+	services := append([]Service(nil), t.Services...)
+	for _, group := range t.RouteGroups {
+		services = append(services, Service{
+			Pos:     group.Pos,
+			Name:    GroupServiceName(group.Name),
+			Methods: group.AllMethods(),
+		})
+	}
+	if len(t.StandaloneRoutes) > 0 {
+		methods := make([]Method, 0, len(t.StandaloneRoutes))
+		for _, route := range t.StandaloneRoutes {
+			methods = append(methods, route.Method)
+		}
+		services = append(services, Service{
+			Name:    StandaloneServiceName,
+			Methods: methods,
+		})
+	}
+	return services
 }
 
 // StandaloneRoute 表示独立路由
@@ -85,12 +172,35 @@ type Options struct {
 	GenerateService     bool   // 是否生成 service 实现
 	MiddlewareOutputDir string // Middleware 实现输出目录
 	GenerateMiddleware  bool   // 是否生成 middleware 实现
+	PruneMiddleware     bool   // 是否删除孤立的 middleware 实现（需确认）
+	Docs                bool   // 是否生成内嵌 OpenAPI 文档和 Swagger UI 的 docs.go
+	Client              bool   // 是否生成通过 HTTP 实现服务接口的 client.go
+	Validate            bool   // 是否为请求类型生成按 binding 标签校验的 Validate 方法
+	Envelope            string // 响应包装：standard 或 .gin 中定义的类型名，为空时不包装
+	EnvelopeFunc        string // 创建自定义响应包装的构造函数名
+	EnvelopePos         Pos    // envelope 配置值的位置
+	PackageNamePos      Pos    // packageName 配置值的位置
 }
 
 // ParseGinTemplate 解析 gin 模板文件
 func ParseGinTemplate(content string) (*GinTemplate, error) {
-	lines := strings.Split(content, "\n")
+	return ParseGinTemplateFile("", content)
+}
+
+// ParseGinTemplateFile 解析 gin 模板文件及其导入的文件，filename 用于错误信息中的位置和解析导入路径
+// 存在语法错误时返回的 error 为 Diagnostics，包含文件中的全部错误和警告
+func ParseGinTemplateFile(filename, content string) (*GinTemplate, error) {
+	file, imports, diags := LoadFile(filename, content)
+	if err := diags.Err(); err != nil {
+		return nil, err
+	}
+	return BuildTemplate(file, imports...), nil
+}
+
+// BuildTemplate 从语法树构建 GinTemplate，导入文件中的类型、校验规则和枚举排在当前文件的之前
+func BuildTemplate(file *File, imports ...*File) *GinTemplate {
 	template := &GinTemplate{
+		File:             file.Name,
 		Types:            make([]Type, 0),
 		Services:         make([]Service, 0),
 		RouteGroups:      make([]RouteGroup, 0),
@@ -98,663 +208,208 @@ func ParseGinTemplate(content string) (*GinTemplate, error) {
 		Options:          Options{},
 	}
 
-	var currentService *Service
-	var currentType *Type
-	var currentRouteGroup *RouteGroup
-	var inType bool
-	var inTypeGroup bool
-
-	var pendingComment string // 用于收集类型上方的注释
-
-	for i, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
-
-		// 收集注释行
-		if strings.HasPrefix(line, "//") {
-			comment := strings.TrimSpace(strings.TrimPrefix(line, "//"))
-			if pendingComment == "" {
-				pendingComment = comment
-			} else {
-				pendingComment += " " + comment
+	if file.Info != nil {
+		for _, entry := range file.Info.Entries {
+			switch entry.Key {
+			case "title":
+				template.Info.Title = entry.Value
+			case "version":
+				template.Info.Version = entry.Value
+			case "desc":
+				template.Info.Desc = entry.Value
 			}
-			continue
-		}
-
-		// 解析 info 块
-		if strings.HasPrefix(line, "info") {
-			if err := parseInfo(lines, i, &template.Info); err != nil {
-				return nil, err
-			}
-			continue
 		}
+	}
 
-		// 解析 options 块
-		if strings.HasPrefix(line, "options") {
-			if err := parseOptions(lines, i, &template.Options); err != nil {
-				return nil, err
+	if file.Options != nil {
+		for _, entry := range file.Options.Entries {
+			applyOption(&template.Options, entry.Key, entry.Value)
+			switch entry.Key {
+			case "envelope":
+				template.Options.EnvelopePos = entry.ValuePos
+			case "packageName":
+				template.Options.PackageNamePos = entry.ValuePos
 			}
-			continue
 		}
+	}
 
-		// 解析 type 定义
-		if strings.HasPrefix(line, "type ") {
-			// 检查是否是 type ( ) 格式
-			if strings.Contains(line, "(") {
-				// 解析 type ( xxxReq { } ) 格式
-				nextIndex, err := parseTypeGroup(lines, i, template, pendingComment)
-				if err != nil {
-					return nil, err
-				}
-				// 跳过已解析的行
-				i = nextIndex
-				// 确保状态正确
-				inType = false
-				inTypeGroup = false
-				currentType = nil
-				pendingComment = "" // 清空注释
-				continue
-			}
-
-			// 原有的单个 type 定义格式
-			typeName := strings.TrimSpace(strings.TrimPrefix(line, "type "))
-			// 移除可能的大括号和 struct 关键字
-			typeName = strings.TrimSpace(strings.Trim(typeName, "{}"))
-
-			// 检查是否为类型别名 (A = B 或 A B 格式)
-			if strings.Contains(typeName, " = ") {
-				// A = B 格式
-				parts := strings.Split(typeName, " = ")
-				if len(parts) == 2 {
-					aliasName := strings.TrimSpace(parts[0])
-					aliasTo := strings.TrimSpace(parts[1])
-					aliasType := &Type{
-						Name:    aliasName,
-						IsAlias: true,
-						AliasTo: aliasTo,
-						Fields:  make([]Field, 0),
-					}
-					template.Types = append(template.Types, *aliasType)
-					continue
-				}
-			} else if !strings.Contains(typeName, " struct") && !strings.Contains(typeName, "{") {
-				// A B 格式 (没有 struct 关键字和大括号)
-				parts := strings.Fields(typeName)
-				if len(parts) == 2 {
-					aliasName := strings.TrimSpace(parts[0])
-					aliasTo := strings.TrimSpace(parts[1])
-					aliasType := &Type{
-						Name:    aliasName,
-						IsAlias: true,
-						AliasTo: aliasTo,
-						Fields:  make([]Field, 0),
-					}
-					template.Types = append(template.Types, *aliasType)
-					continue
-				}
-			}
-
-			// 如果包含 struct 关键字，只取前面的部分
-			if strings.Contains(typeName, " struct") {
-				typeName = strings.TrimSpace(strings.Split(typeName, " struct")[0])
-			}
-			currentType = &Type{Name: typeName, Comment: pendingComment, Fields: make([]Field, 0)}
-			template.Types = append(template.Types, *currentType)
-			inType = true
-			pendingComment = "" // 清空注释
-			continue
+	for _, imported := range imports {
+		for _, spec := range imported.Types {
+			template.Types = append(template.Types, buildType(spec))
 		}
-
-		// 解析 service 定义
-		if strings.HasPrefix(line, "service ") {
-			serviceLine := strings.TrimSpace(strings.TrimPrefix(line, "service "))
-			// 移除可能的大括号
-			serviceLine = strings.TrimSpace(strings.Trim(serviceLine, "{}"))
-
-			// 解析服务名和可选的 prefix
-			parts := strings.Fields(serviceLine)
-			serviceName := parts[0]
-			prefix := ""
-
-			// 检查是否有 prefix 参数
-			if len(parts) >= 3 && parts[1] == "prefix" {
-				prefix = parts[2]
-			}
-
-			currentService = &Service{
-				Name:        serviceName,
-				Prefix:      prefix,
-				Middleware:  make([]string, 0),
-				Methods:     make([]Method, 0),
-				RouteGroups: make([]RouteGroup, 0),
-			}
-			template.Services = append(template.Services, *currentService)
-			inType = false
-			inTypeGroup = false
-			continue
+		for _, decl := range imported.Validators {
+			template.Validators = append(template.Validators, buildValidator(decl))
 		}
-
-		// 解析路由分组定义（在 service 内部）
-		if strings.HasPrefix(line, "group ") && currentService != nil {
-			// 解析 group @admin /v1/admin 格式
-			groupLine := strings.TrimSpace(strings.TrimPrefix(line, "group "))
-			groupLine = strings.TrimSpace(strings.Trim(groupLine, "{}"))
-
-			parts := strings.Fields(groupLine)
-			var groupName, groupPath string
-			if len(parts) >= 2 {
-				groupName = strings.TrimPrefix(parts[0], "@")
-				groupPath = parts[1]
-			} else if len(parts) == 1 {
-				groupPath = parts[0]
-				groupName = strings.ReplaceAll(strings.TrimPrefix(groupPath, "/"), "/", "")
-			}
-
-			currentRouteGroup = &RouteGroup{
-				Name:    groupName,
-				Path:    groupPath,
-				Methods: make([]Method, 0),
-			}
-
-			// 添加到当前服务的路由分组
-			for i, s := range template.Services {
-				if s.Name == currentService.Name {
-					template.Services[i].RouteGroups = append(template.Services[i].RouteGroups, *currentRouteGroup)
-					break
-				}
-			}
-			inType = false
-			continue
-		}
-
-		// 检查是否结束当前路由分组
-		if currentRouteGroup != nil && strings.HasPrefix(line, "}") {
-			currentRouteGroup = nil
-			continue
+		for _, decl := range imported.Enums {
+			template.Enums = append(template.Enums, buildEnum(decl))
 		}
+	}
+	for _, spec := range file.Types {
+		template.Types = append(template.Types, buildType(spec))
+	}
+	for _, decl := range file.Validators {
+		template.Validators = append(template.Validators, buildValidator(decl))
+	}
+	for _, decl := range file.Enums {
+		template.Enums = append(template.Enums, buildEnum(decl))
+	}
+	template.applyEnumRules()
 
-		// 解析独立的路由分组定义（不在 service 内部）
-		if strings.HasPrefix(line, "group ") && currentService == nil {
-			groupPath := strings.TrimSpace(strings.TrimPrefix(line, "group "))
-			groupPath = strings.TrimSpace(strings.Trim(groupPath, "{}"))
-			currentRouteGroup = &RouteGroup{Path: groupPath, Methods: make([]Method, 0)}
-			template.RouteGroups = append(template.RouteGroups, *currentRouteGroup)
-			inType = false
-			continue
+	for _, decl := range file.Services {
+		service := Service{
+			Pos:         decl.Pos,
+			Name:        decl.Name,
+			Prefix:      decl.Prefix,
+			Middleware:  middlewareNames(decl.Middleware),
+			Methods:     make([]Method, 0),
+			RouteGroups: make([]RouteGroup, 0),
 		}
-
-		// 解析独立路由（以 @ 开头但不在 service 或 group 中）
-		if strings.HasPrefix(line, "@") && currentService == nil && currentRouteGroup == nil {
-			method, err := parseMethod(line)
-			if err != nil {
-				return nil, err
-			}
-			template.StandaloneRoutes = append(template.StandaloneRoutes, StandaloneRoute{Method: method})
-			continue
+		for _, method := range decl.Methods {
+			service.Methods = append(service.Methods, buildMethod(method))
 		}
-
-		// 解析字段定义
-		if inType && !inTypeGroup && currentType != nil && strings.Contains(line, " ") && !strings.HasPrefix(line, "}") {
-			field, err := parseField(line)
-			if err != nil {
-				return nil, err
-			}
-			// 更新当前类型
-			for i, t := range template.Types {
-				if t.Name == currentType.Name {
-					template.Types[i].Fields = append(template.Types[i].Fields, field)
-					break
-				}
-			}
-			continue
-		}
-
-		// 解析服务级别中间件
-		if currentService != nil && currentRouteGroup == nil && strings.HasPrefix(line, "middleware:") {
-			middlewareStr := strings.TrimSpace(strings.TrimPrefix(line, "middleware:"))
-			middlewareStr = strings.TrimSpace(strings.Trim(middlewareStr, "[]"))
-			if middlewareStr != "" {
-				middlewareParts := strings.Split(middlewareStr, ",")
-				for _, part := range middlewareParts {
-					// 忽略 // 后面的注释内容
-					if commentIndex := strings.Index(part, "//"); commentIndex != -1 {
-						part = part[:commentIndex]
-					}
-					part = strings.TrimSpace(strings.Trim(part, `"'`))
-					// 过滤中文注释和空字符串
-					if part != "" && !strings.Contains(part, "只包含") {
-						// 更新当前服务的中间件
-						for i, s := range template.Services {
-							if s.Name == currentService.Name {
-								template.Services[i].Middleware = append(template.Services[i].Middleware, part)
-								break
-							}
-						}
-					}
-				}
-			}
-			continue
+		for _, group := range decl.Groups {
+			service.RouteGroups = append(service.RouteGroups, buildRouteGroup(group))
 		}
+		template.Services = append(template.Services, service)
+	}
 
-		// 解析路由分组中间件
-		if currentRouteGroup != nil && strings.HasPrefix(line, "middleware:") {
-			middlewareStr := strings.TrimSpace(strings.TrimPrefix(line, "middleware:"))
-			middlewareStr = strings.TrimSpace(strings.Trim(middlewareStr, "[]"))
-			if middlewareStr != "" {
-				middlewareParts := strings.Split(middlewareStr, ",")
-				for _, part := range middlewareParts {
-					// 忽略 // 后面的注释内容
-					if commentIndex := strings.Index(part, "//"); commentIndex != -1 {
-						part = part[:commentIndex]
-					}
-					part = strings.TrimSpace(strings.Trim(part, `"'`))
-					// 过滤中文注释和空字符串
-					if part != "" && !strings.Contains(part, "只包含") {
-						// 更新当前路由分组的中间件
-						if currentService != nil {
-							// 在服务内部的分组
-							for i, s := range template.Services {
-								if s.Name == currentService.Name {
-									for j, g := range s.RouteGroups {
-										if g.Name == currentRouteGroup.Name && g.Path == currentRouteGroup.Path {
-											template.Services[i].RouteGroups[j].Middleware = append(template.Services[i].RouteGroups[j].Middleware, part)
-											break
-										}
-									}
-									break
-								}
-							}
-						} else {
-							// 独立的分组
-							for i, g := range template.RouteGroups {
-								if g.Path == currentRouteGroup.Path {
-									template.RouteGroups[i].Middleware = append(template.RouteGroups[i].Middleware, part)
-									break
-								}
-							}
-						}
-					}
-				}
-			}
-			continue
-		}
+	for _, group := range file.Groups {
+		template.RouteGroups = append(template.RouteGroups, buildRouteGroup(group))
+	}
 
-		// 解析方法定义
-		if (currentService != nil || currentRouteGroup != nil) && !inType {
-			if strings.Contains(line, "@") {
-				method, err := parseMethod(line)
-				if err != nil {
-					return nil, err
-				}
-
-				// 更新当前服务（只有在不在路由分组内时才添加）
-				if currentService != nil && currentRouteGroup == nil {
-					for i, s := range template.Services {
-						if s.Name == currentService.Name {
-							template.Services[i].Methods = append(template.Services[i].Methods, method)
-							break
-						}
-					}
-				}
-
-				// 更新当前路由分组
-				if currentRouteGroup != nil {
-					if currentService != nil {
-						// 在服务内部的分组
-						for i, s := range template.Services {
-							if s.Name == currentService.Name {
-								for j, g := range s.RouteGroups {
-									if g.Name == currentRouteGroup.Name && g.Path == currentRouteGroup.Path {
-										template.Services[i].RouteGroups[j].Methods = append(template.Services[i].RouteGroups[j].Methods, method)
-										break
-									}
-								}
-								break
-							}
-						}
-					} else {
-						// 独立的分组
-						for i, g := range template.RouteGroups {
-							if g.Path == currentRouteGroup.Path {
-								template.RouteGroups[i].Methods = append(template.RouteGroups[i].Methods, method)
-								break
-							}
-						}
-					}
-				}
-				continue
-			}
-		}
+	for _, route := range file.Routes {
+		template.StandaloneRoutes = append(template.StandaloneRoutes, StandaloneRoute{Method: buildMethod(route)})
 	}
 
-	return template, nil
+	return template
 }
 
-func parseInfo(lines []string, start int, info *Info) error {
-	for i := start + 1; i < len(lines); i++ {
-		line := strings.TrimSpace(lines[i])
-		if line == "" || strings.HasPrefix(line, "}") {
-			break
-		}
-		if strings.HasPrefix(line, "//") {
-			continue
-		}
-
-		parts := strings.SplitN(line, " ", 2)
-		if len(parts) != 2 {
-			continue
-		}
-
-		key := strings.TrimSpace(parts[0])
-		value := strings.TrimSpace(strings.Trim(parts[1], `"`))
-
-		switch key {
-		case "title":
-			info.Title = value
-		case "version":
-			info.Version = value
-		case "desc":
-			info.Desc = value
-		}
-	}
-	return nil
+// optionKeys options 块支持的配置项
+var optionKeys = map[string]bool{
+	"withGinContext":   true,
+	"outputDir":        true,
+	"packageName":      true,
+	"serviceOutputDir": true,
+	"generateService":  true,
+	"docs":             true,
+	"client":           true,
+	"validate":         true,
+	"envelope":         true,
+	"envelopeFunc":     true,
 }
 
-func parseOptions(lines []string, start int, options *Options) error {
-	for i := start + 1; i < len(lines); i++ {
-		line := strings.TrimSpace(lines[i])
-		if line == "" || strings.HasPrefix(line, "}") {
-			break
-		}
-		if strings.HasPrefix(line, "//") {
-			continue
-		}
-
-		parts := strings.SplitN(line, " ", 2)
-		if len(parts) != 2 {
-			continue
-		}
-
-		key := strings.TrimSpace(strings.TrimSuffix(parts[0], ":"))
-		value := strings.TrimSpace(strings.Trim(parts[1], `"`))
-
-		switch key {
-		case "withGinContext":
-			options.WithGinContext = value == "true"
-		case "outputDir":
-			options.OutputDir = value
-		case "packageName":
-			options.PackageName = value
-		case "serviceOutputDir":
-			options.ServiceOutputDir = value
-		case "generateService":
-			options.GenerateService = value == "true"
-		}
+// applyOption 设置单个选项
+func applyOption(options *Options, key, value string) {
+	switch key {
+	case "withGinContext":
+		options.WithGinContext = value == "true"
+	case "outputDir":
+		options.OutputDir = value
+	case "packageName":
+		options.PackageName = value
+	case "serviceOutputDir":
+		options.ServiceOutputDir = value
+	case "generateService":
+		options.GenerateService = value == "true"
+	case "docs":
+		options.Docs = value == "true"
+	case "client":
+		options.Client = value == "true"
+	case "validate":
+		options.Validate = value == "true"
+	case "envelope":
+		options.Envelope = value
+	case "envelopeFunc":
+		options.EnvelopeFunc = value
 	}
-	return nil
 }
 
-func parseField(line string) (Field, error) {
-	// 解析字段格式: name type `tag` // comment 或嵌入字段: TypeName
-	// 支持数组类型如 []User, map[string]interface{} 等
-	// 支持指针类型如 *User 等
-	// 支持嵌入字段如 Base
-
-	// 首先尝试解析嵌入字段（没有字段名，只有类型名）
-	// 嵌入字段格式：TypeName 或 TypeName `tag` 或 TypeName `tag` // comment
-	embeddedRe := regexp.MustCompile(`^(\w+)\s*` + "`([^`]*)`" + `\s*(?://\s*(.*))?$`)
-	embeddedMatches := embeddedRe.FindStringSubmatch(line)
-	if len(embeddedMatches) >= 3 {
-		// 这是带 tag 的嵌入字段
-		field := Field{
-			Name: "", // 嵌入字段没有名称
-			Type: embeddedMatches[1],
-			Tag:  embeddedMatches[2],
-		}
-
-		if len(embeddedMatches) > 3 && embeddedMatches[3] != "" {
-			field.Comment = strings.TrimSpace(embeddedMatches[3])
-		}
-
-		return field, nil
+func buildType(spec *TypeSpec) Type {
+	t := Type{
+		Pos:     spec.Pos,
+		Name:    spec.Name,
+		Comment: spec.Doc,
+		Fields:  make([]Field, 0),
+		IsAlias: spec.IsAlias,
+		AliasTo: spec.AliasTo,
 	}
-
-	// 尝试解析没有 tag 的嵌入字段：TypeName 或 TypeName // comment
-	embeddedNoTagRe := regexp.MustCompile(`^(\w+)\s*(?://\s*(.*))?$`)
-	embeddedNoTagMatches := embeddedNoTagRe.FindStringSubmatch(line)
-	if len(embeddedNoTagMatches) >= 2 {
-		// 检查是否包含空格和反引号，如果有则不是嵌入字段
-		// 嵌入字段不应该包含空格（除了可能的注释）和反引号
-		if !strings.Contains(line, "`") && (strings.Count(line, " ") <= 1 || strings.Contains(line, "//")) {
-			// 这是没有 tag 的嵌入字段
-			field := Field{
-				Name: "", // 嵌入字段没有名称
-				Type: embeddedNoTagMatches[1],
-				Tag:  "",
-			}
-
-			if len(embeddedNoTagMatches) > 2 && embeddedNoTagMatches[2] != "" {
-				field.Comment = strings.TrimSpace(embeddedNoTagMatches[2])
-			}
-
-			return field, nil
-		}
+	for _, decl := range spec.Fields {
+		t.Fields = append(t.Fields, Field{
+			Pos:     decl.Pos,
+			Name:    decl.Name,
+			Type:    decl.Type,
+			TypePos: decl.TypePos,
+			Tag:     decl.Tag,
+			TagPos:  decl.TagPos,
+			Comment: decl.Comment,
+			// 检查是否必填
+			Required: decl.Name != "" && strings.Contains(decl.Tag, "required"),
+		})
 	}
+	return t
+}
 
-	// 解析普通字段格式: name type `tag` // comment
-	re := regexp.MustCompile(`(\w+)\s+([\w\[\]{}.,*]+)\s+` + "`([^`]*)`" + `\s*(?://\s*(.*))?`)
-	matches := re.FindStringSubmatch(line)
-	if len(matches) < 4 {
-		return Field{}, fmt.Errorf("invalid field format: %s", line)
+func buildRouteGroup(decl *GroupDecl) RouteGroup {
+	group := RouteGroup{
+		Pos:        decl.Pos,
+		Name:       decl.Name,
+		Path:       decl.Path,
+		Middleware: middlewareNames(decl.Middleware),
+		Skip:       skipNames(decl.Middleware),
+		Methods:    make([]Method, 0),
+		Groups:     make([]RouteGroup, 0),
 	}
-
-	field := Field{
-		Name: matches[1],
-		Type: matches[2],
-		Tag:  matches[3],
+	for _, method := range decl.Methods {
+		group.Methods = append(group.Methods, buildMethod(method))
 	}
-
-	// 检查是否必填
-	field.Required = strings.Contains(field.Tag, "required")
-
-	// 注释是可选的，有就添加，没有就空着
-	if len(matches) > 4 && matches[4] != "" {
-		field.Comment = strings.TrimSpace(matches[4])
+	for _, child := range decl.Groups {
+		group.Groups = append(group.Groups, buildRouteGroup(child))
 	}
-
-	return field, nil
+	return group
 }
 
-func parseMethod(line string) (Method, error) {
-	// 解析方法格式: @method httpMethod path [WithGinContext] request response [middleware: ["mw1", "mw2"]] // comment
-
-	// 提取中间件部分
-	middleware := []string{}
-	if strings.Contains(line, "middleware:") {
-		reMiddleware := regexp.MustCompile(`middleware:\s*\[([^\]]+)\]`)
-		matches := reMiddleware.FindStringSubmatch(line)
-		if len(matches) > 1 {
-			middlewareStr := strings.TrimSpace(matches[1])
-			if middlewareStr != "" {
-				// 解析中间件列表
-				middlewareParts := strings.Split(middlewareStr, ",")
-				for _, part := range middlewareParts {
-					part = strings.TrimSpace(strings.Trim(part, `"'`))
-					if part != "" {
-						middleware = append(middleware, part)
-					}
-				}
-			}
-		}
-		// 移除中间件部分以便后续解析
-		line = reMiddleware.ReplaceAllString(line, "")
+func buildMethod(decl *MethodDecl) Method {
+	// 行尾注释优先，其次使用方法上方的注释
+	description := decl.Comment
+	if description == "" {
+		description = decl.Doc
 	}
-
-	// 先尝试解析包含 WithGinContext 的格式
-	reWithGin := regexp.MustCompile(`@(\w+)\s+(\w+)\s+(\S+)\s+WithGinContext\s+(\w+)\s+(\w+)\s*(?://\s*(.*))?`)
-	matches := reWithGin.FindStringSubmatch(line)
-	if len(matches) >= 6 {
-		method := Method{
-			Name:           matches[1],
-			HTTPMethod:     strings.ToUpper(matches[2]),
-			Path:           matches[3],
-			Request:        matches[4],
-			Response:       matches[5],
-			WithGinContext: true,
-			Middleware:     middleware,
-		}
-		if len(matches) > 6 {
-			method.Description = strings.TrimSpace(matches[6])
-		}
-		return method, nil
+	return Method{
+		Pos:            decl.Pos,
+		Name:           decl.Name,
+		HTTPMethod:     decl.HTTPMethod,
+		Path:           decl.Path,
+		PathPos:        decl.PathPos,
+		Request:        decl.Request,
+		RequestPos:     decl.RequestPos,
+		Response:       decl.Response,
+		ResponsePos:    decl.ResponsePos,
+		Status:         decl.Status,
+		Description:    description,
+		WithGinContext: decl.WithGinContext,
+		Middleware:     middlewareNames(decl.Middleware),
+		Skip:           skipNames(decl.Middleware),
 	}
+}
 
-	// 如果没有 WithGinContext，解析普通格式
-	reNormal := regexp.MustCompile(`@(\w+)\s+(\w+)\s+(\S+)\s+(\w+)\s+(\w+)\s*(?://\s*(.*))?`)
-	matches = reNormal.FindStringSubmatch(line)
-	if len(matches) >= 6 {
-		method := Method{
-			Name:           matches[1],
-			HTTPMethod:     strings.ToUpper(matches[2]),
-			Path:           matches[3],
-			Request:        matches[4],
-			Response:       matches[5],
-			WithGinContext: false,
-			Middleware:     middleware,
-		}
-		if len(matches) > 6 {
-			method.Description = strings.TrimSpace(matches[6])
+// middlewareNames 提取中间件名称列表
+func middlewareNames(entries []*MiddlewareEntry) []string {
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Name != "" && !entry.Skip {
+			names = append(names, entry.Name)
 		}
-		return method, nil
 	}
-
-	return Method{}, fmt.Errorf("invalid method format: %s", line)
+	return names
 }
 
-// parseTypeGroup 解析 type ( ) 格式的类型定义组
-func parseTypeGroup(lines []string, start int, template *GinTemplate, groupComment string) (int, error) {
-	// 找到 type ( 行
-	line := strings.TrimSpace(lines[start])
-	if !strings.HasPrefix(line, "type ") || !strings.Contains(line, "(") {
-		return start, fmt.Errorf("invalid type group format")
-	}
-
-	// 从下一行开始解析，直到找到对应的 )
-	var currentComment string // 用于收集当前类型的注释
-	for i := start + 1; i < len(lines); i++ {
-		line := strings.TrimSpace(lines[i])
-
-		// 跳过空行
-		if line == "" {
-			continue
-		}
-
-		// 收集注释行
-		if strings.HasPrefix(line, "//") {
-			comment := strings.TrimSpace(strings.TrimPrefix(line, "//"))
-			if currentComment == "" {
-				currentComment = comment
-			} else {
-				currentComment += " " + comment
-			}
-			continue
-		}
-
-		// 如果遇到 )，说明类型组结束
-		if line == ")" {
-			return i, nil
-		}
-
-		// 检查是否为类型别名 (A = B 或 A B 格式)
-		if strings.Contains(line, " = ") {
-			// A = B 格式
-			parts := strings.Split(line, " = ")
-			if len(parts) == 2 {
-				aliasName := strings.TrimSpace(parts[0])
-				aliasTo := strings.TrimSpace(parts[1])
-				aliasType := &Type{
-					Name:    aliasName,
-					Comment: currentComment,
-					IsAlias: true,
-					AliasTo: aliasTo,
-					Fields:  make([]Field, 0),
-				}
-				template.Types = append(template.Types, *aliasType)
-				currentComment = "" // 清空注释
-				continue
-			}
-		} else if !strings.Contains(line, "{") && !strings.Contains(line, " struct") {
-			// A B 格式 (没有大括号和 struct 关键字)
-			parts := strings.Fields(line)
-			if len(parts) == 2 {
-				aliasName := strings.TrimSpace(parts[0])
-				aliasTo := strings.TrimSpace(parts[1])
-				aliasType := &Type{
-					Name:    aliasName,
-					Comment: currentComment,
-					IsAlias: true,
-					AliasTo: aliasTo,
-					Fields:  make([]Field, 0),
-				}
-				template.Types = append(template.Types, *aliasType)
-				currentComment = "" // 清空注释
-				continue
-			}
-		}
-
-		// 解析单个类型定义
-		if strings.Contains(line, "{") {
-			// 解析 xxxReq { } 格式
-			parts := strings.Fields(line)
-			if len(parts) >= 2 {
-				typeName := parts[0]
-				// 移除可能的大括号和 struct 关键字
-				typeName = strings.TrimSpace(strings.Trim(typeName, "{}"))
-				// 如果包含 struct 关键字，只取前面的部分
-				if strings.Contains(typeName, " struct") {
-					typeName = strings.TrimSpace(strings.Split(typeName, " struct")[0])
-				}
-
-				// 创建类型
-				currentType := &Type{Name: typeName, Comment: currentComment, Fields: make([]Field, 0)}
-
-				// 检查是否在同一行有字段定义
-				if strings.Contains(line, "{") && strings.Contains(line, "}") {
-					// 单行类型定义，没有字段
-					template.Types = append(template.Types, *currentType)
-					currentComment = "" // 清空注释
-				} else if strings.Contains(line, "{") && !strings.Contains(line, "}") {
-					// 多行类型定义，继续解析字段
-					for j := i + 1; j < len(lines); j++ {
-						fieldLine := strings.TrimSpace(lines[j])
-
-						// 跳过空行和注释
-						if fieldLine == "" || strings.HasPrefix(fieldLine, "//") {
-							continue
-						}
-
-						// 如果遇到 }，说明字段定义结束
-						if fieldLine == "}" {
-							break
-						}
-
-						// 解析字段
-						if !strings.HasPrefix(fieldLine, "}") {
-							field, err := parseField(fieldLine)
-							if err != nil {
-								return start, err
-							}
-							currentType.Fields = append(currentType.Fields, field)
-						}
-					}
-					template.Types = append(template.Types, *currentType)
-					currentComment = "" // 清空注释
-				}
-			}
+// skipNames 提取需要跳过的上级中间件名称列表
+func skipNames(entries []*MiddlewareEntry) []string {
+	names := make([]string, 0)
+	for _, entry := range entries {
+		if entry.Name != "" && entry.Skip {
+			names = append(names, entry.Name)
 		}
 	}
-
-	return start, nil
+	return names
 }
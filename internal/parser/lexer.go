@@ -0,0 +1,297 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// tokenKind 表示词法单元类型
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIllegal
+	tokNewline
+	tokComment
+	tokIdent
+	tokInt
+	tokString
+	tokRawString
+	tokPath
+	tokLBrace
+	tokRBrace
+	tokLParen
+	tokRParen
+	tokLBrack
+	tokRBrack
+	tokComma
+	tokColon
+	tokSemicolon
+	tokAssign
+	tokAt
+	tokStar
+	tokDot
+	tokMinus
+)
+
+var tokenNames = map[tokenKind]string{
+	tokEOF:       "文件结尾",
+	tokIllegal:   "非法字符",
+	tokNewline:   "换行",
+	tokComment:   "注释",
+	tokIdent:     "标识符",
+	tokInt:       "整数",
+	tokString:    "字符串",
+	tokRawString: "反引号字符串",
+	tokPath:      "路径",
+	tokLBrace:    "'{'",
+	tokRBrace:    "'}'",
+	tokLParen:    "'('",
+	tokRParen:    "')'",
+	tokLBrack:    "'['",
+	tokRBrack:    "']'",
+	tokComma:     "','",
+	tokColon:     "':'",
+	tokSemicolon: "';'",
+	tokAssign:    "'='",
+	tokAt:        "'@'",
+	tokStar:      "'*'",
+	tokDot:       "'.'",
+	tokMinus:     "'-'",
+}
+
+// String 返回词法单元类型的描述
+func (k tokenKind) String() string {
+	if name, ok := tokenNames[k]; ok {
+		return name
+	}
+	return "未知"
+}
+
+// token 表示一个词法单元
+type token struct {
+	kind   tokenKind
+	text   string // 源码原文
+	value  string // 字符串解码后的值、注释内容或非法字符的错误说明
+	code   string // 非法词法单元的诊断代码
+	pos    Pos
+	offset int // 起始字节偏移
+	end    int // 结束字节偏移
+}
+
+// describe 返回用于错误信息的词法单元描述
+func (t token) describe() string {
+	switch t.kind {
+	case tokEOF, tokNewline:
+		return t.kind.String()
+	case tokComment:
+		return "注释"
+	default:
+		return "'" + t.text + "'"
+	}
+}
+
+// lexer 将 .gin 源码切分为词法单元
+type lexer struct {
+	file   string
+	src    string
+	offset int
+	line   int
+	column int
+}
+
+// tokenize 对源码做词法分析，结果总是以 tokEOF 结尾
+func tokenize(file, src string) []token {
+	l := &lexer{file: file, src: src, line: 1, column: 1}
+	var tokens []token
+	for {
+		tok := l.next()
+		tokens = append(tokens, tok)
+		if tok.kind == tokEOF {
+			return tokens
+		}
+	}
+}
+
+// peekRune 返回当前位置之后第 n 个字符
+func (l *lexer) peekRune(n int) rune {
+	offset := l.offset
+	for i := 0; i < n; i++ {
+		if offset >= len(l.src) {
+			return -1
+		}
+		_, size := utf8.DecodeRuneInString(l.src[offset:])
+		offset += size
+	}
+	if offset >= len(l.src) {
+		return -1
+	}
+	r, _ := utf8.DecodeRuneInString(l.src[offset:])
+	return r
+}
+
+// advance 前进一个字符并维护行列号
+func (l *lexer) advance() rune {
+	r, size := utf8.DecodeRuneInString(l.src[l.offset:])
+	l.offset += size
+	if r == '\n' {
+		l.line++
+		l.column = 1
+	} else {
+		l.column++
+	}
+	return r
+}
+
+func (l *lexer) pos() Pos {
+	return Pos{File: l.file, Line: l.line, Column: l.column}
+}
+
+// next 读取下一个词法单元
+func (l *lexer) next() token {
+	// 跳过空白（换行除外）
+	for l.offset < len(l.src) {
+		r := l.peekRune(0)
+		if r == '\n' || !unicode.IsSpace(r) {
+			break
+		}
+		l.advance()
+	}
+
+	start := l.offset
+	pos := l.pos()
+	tok := token{pos: pos, offset: start}
+	finish := func(kind tokenKind) token {
+		tok.kind = kind
+		tok.end = l.offset
+		tok.text = l.src[start:l.offset]
+		return tok
+	}
+
+	if l.offset >= len(l.src) {
+		return finish(tokEOF)
+	}
+
+	r := l.advance()
+	switch {
+	case r == '\n':
+		return finish(tokNewline)
+	case r == '/' && l.peekRune(0) == '/':
+		for l.offset < len(l.src) && l.peekRune(0) != '\n' {
+			l.advance()
+		}
+		tok = finish(tokComment)
+		tok.value = strings.TrimSpace(strings.TrimPrefix(tok.text, "//"))
+		return tok
+	case r == '/':
+		for l.offset < len(l.src) && isPathRune(l.peekRune(0)) {
+			l.advance()
+		}
+		return finish(tokPath)
+	case r == '_' || unicode.IsLetter(r):
+		for l.offset < len(l.src) {
+			c := l.peekRune(0)
+			if c != '_' && !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+				break
+			}
+			l.advance()
+		}
+		return finish(tokIdent)
+	case unicode.IsDigit(r):
+		for l.offset < len(l.src) && unicode.IsDigit(l.peekRune(0)) {
+			l.advance()
+		}
+		return finish(tokInt)
+	case r == '"':
+		for l.offset < len(l.src) {
+			c := l.peekRune(0)
+			if c == '\n' {
+				break
+			}
+			l.advance()
+			if c == '\\' && l.offset < len(l.src) && l.peekRune(0) != '\n' {
+				l.advance()
+				continue
+			}
+			if c == '"' {
+				tok = finish(tokString)
+				value, err := strconv.Unquote(tok.text)
+				if err != nil {
+					tok.kind = tokIllegal
+					tok.value = "无效的字符串字面量"
+					tok.code = CodeInvalidString
+					return tok
+				}
+				tok.value = value
+				return tok
+			}
+		}
+		tok = finish(tokIllegal)
+		tok.value = "字符串缺少结束引号"
+		tok.code = CodeUnterminated
+		return tok
+	case r == '`':
+		// 反引号字符串只用于字段 tag，不允许跨行
+		for l.offset < len(l.src) && l.peekRune(0) != '\n' {
+			if l.advance() == '`' {
+				tok = finish(tokRawString)
+				tok.value = tok.text[1 : len(tok.text)-1]
+				return tok
+			}
+		}
+		tok = finish(tokIllegal)
+		tok.value = "反引号字符串缺少结束反引号"
+		tok.code = CodeUnterminated
+		return tok
+	}
+
+	switch r {
+	case '{':
+		return finish(tokLBrace)
+	case '}':
+		return finish(tokRBrace)
+	case '(':
+		return finish(tokLParen)
+	case ')':
+		return finish(tokRParen)
+	case '[':
+		return finish(tokLBrack)
+	case ']':
+		return finish(tokRBrack)
+	case ',':
+		return finish(tokComma)
+	case ':':
+		return finish(tokColon)
+	case ';':
+		return finish(tokSemicolon)
+	case '=':
+		return finish(tokAssign)
+	case '@':
+		return finish(tokAt)
+	case '*':
+		return finish(tokStar)
+	case '.':
+		return finish(tokDot)
+	case '-':
+		return finish(tokMinus)
+	}
+
+	tok = finish(tokIllegal)
+	tok.value = "非法字符 " + strconv.QuoteRune(r)
+	tok.code = CodeIllegalChar
+	return tok
+}
+
+// isPathRune 判断字符是否可以出现在路由路径中
+func isPathRune(r rune) bool {
+	if r < 0 || unicode.IsSpace(r) {
+		return false
+	}
+	switch r {
+	case '{', '}', '(', ')', '[', ']', '"', '`', ',', ';':
+		return false
+	}
+	return true
+}
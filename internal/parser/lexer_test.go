@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string // 行:列 类型 原文
+	}{
+		{
+			name: "方法定义",
+			src:  "@Get GET /users/:id Req -\n",
+			want: []string{
+				`1:1 '@' "@"`, `1:2 标识符 "Get"`, `1:6 标识符 "GET"`, `1:10 路径 "/users/:id"`,
+				`1:21 标识符 "Req"`, `1:25 '-' "-"`, `1:26 换行 "\n"`, `2:1 文件结尾 ""`,
+			},
+		},
+		{
+			name: "字段和反引号字符串",
+			src:  "\tID []*int `json:\"id\"` // 编号\n}",
+			want: []string{
+				`1:2 标识符 "ID"`, `1:5 '[' "["`, `1:6 ']' "]"`, `1:7 '*' "*"`, `1:8 标识符 "int"`,
+				"1:12 反引号字符串 \"`json:\\\"id\\\"`\"", `1:24 注释 "// 编号"`, `1:29 换行 "\n"`,
+				`2:1 '}' "}"`, `2:2 文件结尾 ""`,
+			},
+		},
+		{
+			name: "列号按字符计算",
+			src:  `title: "用户" x`,
+			want: []string{`1:1 标识符 "title"`, `1:6 ':' ":"`, `1:8 字符串 "\"用户\""`, `1:13 标识符 "x"`, `1:14 文件结尾 ""`},
+		},
+		{
+			name: "枚举取值",
+			src:  "Banned = -3;A=007",
+			want: []string{
+				`1:1 标识符 "Banned"`, `1:8 '=' "="`, `1:10 '-' "-"`, `1:11 整数 "3"`, `1:12 ';' ";"`,
+				`1:13 标识符 "A"`, `1:14 '=' "="`, `1:15 整数 "007"`, `1:18 文件结尾 ""`,
+			},
+		},
+		{
+			name: "非法字符和未闭合的字符串",
+			src:  "a # \"b\n`c",
+			want: []string{`1:1 标识符 "a"`, `1:3 非法字符 "#"`, `1:5 非法字符 "\"b"`, `1:7 换行 "\n"`, "2:1 非法字符 \"`c\"", `2:3 文件结尾 ""`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, tok := range tokenize("test.gin", tt.src) {
+				got = append(got, fmt.Sprintf("%d:%d %s %q", tok.pos.Line, tok.pos.Column, tok.kind, tok.text))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tokenize() =\n%q\n期望\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenizeValues(t *testing.T) {
+	tests := []struct {
+		src   string
+		kind  tokenKind
+		value string
+		code  string
+	}{
+		{src: `"a\tb\"c"`, kind: tokString, value: "a\tb\"c"},
+		{src: "`^[a-z]+\\d$`", kind: tokRawString, value: `^[a-z]+\d$`},
+		{src: "//  注释  ", kind: tokComment, value: "注释"},
+		{src: `"\q"`, kind: tokIllegal, value: "无效的字符串字面量", code: CodeInvalidString},
+		{src: `"abc`, kind: tokIllegal, value: "字符串缺少结束引号", code: CodeUnterminated},
+		{src: "`abc", kind: tokIllegal, value: "反引号字符串缺少结束反引号", code: CodeUnterminated},
+		{src: "$", kind: tokIllegal, value: "非法字符 '$'", code: CodeIllegalChar},
+	}
+	for _, tt := range tests {
+		tok := tokenize("test.gin", tt.src)[0]
+		if tok.kind != tt.kind || tok.value != tt.value || tok.code != tt.code {
+			t.Errorf("tokenize(%q) = %s %q %s，期望 %s %q %s", tt.src, tok.kind, tok.value, tok.code, tt.kind, tt.value, tt.code)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// loader 递归加载 .gin 文件及其导入的文件
+type loader struct {
+	loaded  map[string]bool // 已加载完成的文件（绝对路径）
+	stack   []string        // 正在加载的文件（绝对路径），用于检测循环导入
+	names   []string        // stack 对应的显示名称
+	imports []*File         // 按依赖顺序排列的导入文件
+	diags   Diagnostics
+}
+
+// LoadFile 解析 .gin 文件并递归加载其中 import 的文件
+// 导入路径相对于导入它的文件所在目录，返回的导入文件按依赖顺序排列，同一文件只加载一次
+func LoadFile(filename, content string) (*File, []*File, Diagnostics) {
+	l := &loader{loaded: make(map[string]bool)}
+	file := l.load(filename, absPath(filename), content)
+
+	for _, imported := range l.imports {
+		l.checkImported(imported)
+	}
+	l.diags.Sort()
+	return file, l.imports, l.diags
+}
+
+func (l *loader) load(name, abs, content string) *File {
+	file, diags := ParseFile(name, content)
+	l.diags = append(l.diags, diags...)
+
+	l.stack = append(l.stack, abs)
+	l.names = append(l.names, name)
+	defer func() {
+		l.stack = l.stack[:len(l.stack)-1]
+		l.names = l.names[:len(l.names)-1]
+		l.loaded[abs] = true
+	}()
+
+	for _, spec := range file.Imports {
+		path := spec.Path
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(filepath.Dir(name), path)
+		}
+		importAbs := absPath(path)
+
+		if i := indexOf(l.stack, importAbs); i >= 0 {
+			cycle := append(append([]string{}, l.names[i:]...), path)
+			l.diags.Errorf(spec.Pos, CodeImportCycle, "循环导入: %s", strings.Join(cycle, " -> ")).
+				WithHint("将共享的类型移到单独的文件中，由双方分别导入")
+			continue
+		}
+		if l.loaded[importAbs] {
+			continue
+		}
+
+		src, err := os.ReadFile(path)
+		if err != nil {
+			l.diags.Errorf(spec.Pos, CodeImportNotFound, "无法读取导入的文件 %s", spec.Path).
+				WithHint("导入路径相对于当前文件所在目录，解析为 %s", path)
+			continue
+		}
+		l.imports = append(l.imports, l.load(path, importAbs, string(src)))
+	}
+	return file
+}
+
+// checkImported 导入的文件只合并类型定义和校验规则，其余声明给出警告
+func (l *loader) checkImported(file *File) {
+	for _, service := range file.Services {
+		l.diags.Warnf(service.Pos, CodeImportedIgnored, "导入的文件中的服务 %s 不会生成代码", service.Name)
+	}
+	for _, group := range file.Groups {
+		l.diags.Warnf(group.Pos, CodeImportedIgnored, "导入的文件中的分组 %s 不会生成代码", group.Name)
+	}
+	for _, route := range file.Routes {
+		l.diags.Warnf(route.Pos, CodeImportedIgnored, "导入的文件中的路由 %s 不会生成代码", route.Name)
+	}
+}
+
+// absPath 返回用于比较的绝对路径
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return filepath.Clean(path)
+}
+
+func indexOf(list []string, s string) int {
+	for i, item := range list {
+		if item == s {
+			return i
+		}
+	}
+	return -1
+}
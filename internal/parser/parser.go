@@ -0,0 +1,939 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 常见语法提示
+const (
+	hintMethod     = "方法定义格式: @方法名 HTTP方法 /路径 [WithGinContext] 请求类型 响应类型 [status:201] [middleware: [...]] [skip: [...]] // 描述，没有请求体或响应体时类型写 -"
+	hintField      = "字段定义格式: 字段名 类型 `tag` // 注释，嵌入字段只写类型名"
+	hintMiddleware = "中间件格式: middleware: [\"auth\", \"-logging\"] 或 skip: [\"logging\"]"
+	hintGroup      = "分组定义格式: group @分组名 /路径 { ... }"
+	hintService    = "服务定义格式: service 服务名 [prefix v1] { ... }"
+	hintType       = "类型定义格式: type Name { ... }、type Name = T 或 type ( ... )"
+	hintImport     = "导入格式: import \"../common/base.gin\" 或 import ( ... )"
+	hintEnum       = "枚举定义格式: enum Name int { Active = 1 // 注释 } 或 enum Name string { Male = \"male\" }"
+	hintValidator  = "校验规则格式: 规则名 regex \"正则表达式\" [zh: \"{0}错误信息\"] [en: \"{0} message\"] // 注释，手动注册的规则使用 规则名 external"
+)
+
+// httpMethods 支持的 HTTP 方法
+var httpMethods = map[string]bool{
+	"GET":     true,
+	"POST":    true,
+	"PUT":     true,
+	"DELETE":  true,
+	"PATCH":   true,
+	"HEAD":    true,
+	"OPTIONS": true,
+}
+
+// parser 递归下降语法分析器
+type parser struct {
+	file   *File
+	src    string
+	tokens []token
+	index  int
+	doc    []string // 待附加到下一个节点的注释
+	diags  Diagnostics
+}
+
+// ParseFile 解析 .gin 文件内容，返回语法树和全部诊断信息
+// 出现语法错误时会跳过出错的语句继续解析，返回的语法树只包含解析成功的部分
+func ParseFile(filename, content string) (*File, Diagnostics) {
+	p := &parser{
+		file:   &File{Name: filename},
+		src:    content,
+		tokens: tokenize(filename, content),
+	}
+	p.parseFile()
+	p.diags.Sort()
+	return p.file, p.diags
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.index]
+}
+
+// peekAt 返回当前位置之后第 n 个词法单元
+func (p *parser) peekAt(n int) token {
+	if p.index+n >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.index+n]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.index]
+	if tok.kind != tokEOF {
+		p.index++
+	}
+	return tok
+}
+
+func (p *parser) errorf(pos Pos, code, format string, args ...interface{}) *Diagnostic {
+	return newDiagnostic(pos, SeverityError, code, format, args...)
+}
+
+// unexpected 返回“意外的词法单元”错误
+func (p *parser) unexpected(tok token, expected string) *Diagnostic {
+	if tok.kind == tokIllegal {
+		return p.errorf(tok.pos, tok.code, "%s", tok.value)
+	}
+	return p.errorf(tok.pos, CodeUnexpectedToken, "期望 %s，实际为 %s", expected, tok.describe())
+}
+
+// reportAndSkip 记录错误并跳到下一条语句，未设置提示时使用 hint
+func (p *parser) reportAndSkip(err error, hint string) {
+	d, ok := err.(*Diagnostic)
+	if !ok {
+		d = p.errorf(p.peek().pos, CodeUnexpectedToken, "%v", err)
+	}
+	if d.Hint == "" {
+		d.Hint = hint
+	}
+	p.diags = append(p.diags, d)
+	p.doc = nil
+
+	start := p.index
+	p.sync()
+	// 保证每次恢复至少前进一个词法单元
+	if p.index == start {
+		p.next()
+	}
+}
+
+// sync 跳过出错语句的剩余部分（包括其后的整个代码块），停在下一条语句的开头
+// 遇到未匹配的 '}' 或 ')' 时停下，由外层代码块处理
+func (p *parser) sync() {
+	depth := 0
+	for {
+		tok := p.peek()
+		switch tok.kind {
+		case tokEOF:
+			return
+		case tokLBrace, tokLParen:
+			depth++
+		case tokRBrace, tokRParen:
+			if depth == 0 {
+				return
+			}
+			depth--
+		case tokNewline, tokSemicolon:
+			if depth == 0 {
+				p.next()
+				return
+			}
+		}
+		p.next()
+	}
+}
+
+func (p *parser) expect(kind tokenKind, expected string) (token, error) {
+	tok := p.peek()
+	if tok.kind != kind {
+		return tok, p.unexpected(tok, expected)
+	}
+	return p.next(), nil
+}
+
+// isKeyword 判断当前词法单元是否为指定关键字
+func (p *parser) isKeyword(word string) bool {
+	tok := p.peek()
+	return tok.kind == tokIdent && tok.text == word
+}
+
+// skipBlank 跳过空行、分号和独占一行的注释，注释会被收集为下一个节点的文档
+func (p *parser) skipBlank() {
+	for {
+		switch p.peek().kind {
+		case tokNewline, tokSemicolon:
+			p.next()
+		case tokComment:
+			p.doc = append(p.doc, p.next().value)
+		default:
+			return
+		}
+	}
+}
+
+// takeDoc 取出并清空已收集的注释
+func (p *parser) takeDoc() string {
+	doc := strings.Join(p.doc, " ")
+	p.doc = nil
+	return doc
+}
+
+// skipNewlines 跳过换行（用于括号内部）
+func (p *parser) skipNewlines() {
+	for p.peek().kind == tokNewline || p.peek().kind == tokComment {
+		p.next()
+	}
+}
+
+// endOfLine 结束一条语句，返回行尾注释
+func (p *parser) endOfLine() (string, error) {
+	comment := ""
+	if p.peek().kind == tokComment {
+		comment = p.next().value
+	}
+	switch p.peek().kind {
+	case tokNewline, tokSemicolon:
+		p.next()
+		return comment, nil
+	case tokRBrace, tokRParen, tokEOF:
+		return comment, nil
+	}
+	return comment, p.unexpected(p.peek(), "换行")
+}
+
+// closeBlock 消费代码块的结束符及其所在行的剩余部分
+func (p *parser) closeBlock() {
+	p.next()
+	p.doc = nil
+	if _, err := p.endOfLine(); err != nil {
+		p.reportAndSkip(err, "")
+	}
+}
+
+// unclosed 返回代码块未闭合的错误
+func (p *parser) unclosed(open token, what string) *Diagnostic {
+	closer := "}"
+	if open.kind == tokLParen {
+		closer = ")"
+	}
+	return p.errorf(p.peek().pos, CodeUnexpectedToken, "%s未闭合，缺少 '%s'", what, closer).
+		WithHint("%s在 %s 处开始", what, open.pos)
+}
+
+func (p *parser) parseFile() {
+	for {
+		p.skipBlank()
+		tok := p.peek()
+		var err error
+		hint := ""
+		switch {
+		case tok.kind == tokEOF:
+			return
+		case tok.kind == tokAt:
+			var method *MethodDecl
+			if method, err = p.parseMethod(); err == nil {
+				p.file.Routes = append(p.file.Routes, method)
+			}
+			hint = hintMethod
+		case tok.kind == tokIdent && tok.text == "import":
+			err = p.parseImportDecl()
+			hint = hintImport
+		case tok.kind == tokIdent && tok.text == "info":
+			var block *KeyValueBlock
+			if block, err = p.parseKeyValueBlock(infoKeys); err == nil {
+				if p.file.Info != nil {
+					p.diags.Warnf(block.Pos, CodeDuplicateBlock, "重复的 info 块，将覆盖之前的定义")
+				}
+				p.file.Info = block
+			}
+		case tok.kind == tokIdent && tok.text == "options":
+			var block *KeyValueBlock
+			if block, err = p.parseKeyValueBlock(optionKeys); err == nil {
+				if p.file.Options != nil {
+					p.diags.Warnf(block.Pos, CodeDuplicateBlock, "重复的 options 块，将覆盖之前的定义")
+				}
+				p.file.Options = block
+			}
+		case tok.kind == tokIdent && tok.text == "type":
+			err = p.parseTypeDecl()
+			hint = hintType
+		case tok.kind == tokIdent && tok.text == "service":
+			var service *ServiceDecl
+			if service, err = p.parseService(); err == nil {
+				p.file.Services = append(p.file.Services, service)
+			}
+			hint = hintService
+		case tok.kind == tokIdent && tok.text == "enum":
+			var enum *EnumDecl
+			if enum, err = p.parseEnum(); err == nil {
+				p.file.Enums = append(p.file.Enums, enum)
+			}
+			hint = hintEnum
+		case tok.kind == tokIdent && tok.text == "validators":
+			err = p.parseValidators()
+		case tok.kind == tokIdent && tok.text == "group":
+			var group *GroupDecl
+			if group, err = p.parseGroup(); err == nil {
+				p.file.Groups = append(p.file.Groups, group)
+			}
+			hint = hintGroup
+		case tok.kind == tokRBrace || tok.kind == tokRParen:
+			err = p.errorf(tok.pos, CodeUnexpectedToken, "多余的 %s", tok.describe()).
+				WithHint("检查括号是否配对")
+		default:
+			err = p.unexpected(tok, "import、info、options、validators、type、enum、service、group 或 @路由")
+		}
+		if err != nil {
+			p.reportAndSkip(err, hint)
+		}
+	}
+}
+
+// infoKeys info 块支持的配置项
+var infoKeys = map[string]bool{
+	"title":   true,
+	"version": true,
+	"desc":    true,
+}
+
+// parseKeyValueBlock 解析 info { ... } 或 options { ... }
+func (p *parser) parseKeyValueBlock(keys map[string]bool) (*KeyValueBlock, error) {
+	keyword := p.next()
+	p.takeDoc()
+	block := &KeyValueBlock{Pos: keyword.pos}
+	open, err := p.expect(tokLBrace, "'{'")
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipBlank()
+		p.doc = nil
+		switch p.peek().kind {
+		case tokRBrace:
+			p.closeBlock()
+			return block, nil
+		case tokEOF:
+			p.diags = append(p.diags, p.unclosed(open, keyword.text+" 块"))
+			return block, nil
+		}
+		entry, err := p.parseKeyValue()
+		if err != nil {
+			p.reportAndSkip(err, "配置项格式: key: value")
+			continue
+		}
+		if !keys[entry.Key] {
+			known := make([]string, 0, len(keys))
+			for key := range keys {
+				known = append(known, key)
+			}
+			sort.Strings(known)
+			p.diags.Warnf(entry.Pos, CodeUnknownKey, "%s 块中未知的配置项 %q，已忽略", keyword.text, entry.Key).
+				WithHint("可用的配置项: %s", strings.Join(known, "、"))
+		}
+		block.Entries = append(block.Entries, entry)
+	}
+}
+
+// parseKeyValue 解析 key: value，值为该行剩余的内容（不包括行尾注释），未加引号的值中不能出现 '{'
+func (p *parser) parseKeyValue() (*KeyValue, error) {
+	tok, err := p.expect(tokIdent, "配置项名称或 '}'")
+	if err != nil {
+		return nil, err
+	}
+	entry := &KeyValue{Pos: tok.pos, Key: tok.text}
+	if p.peek().kind == tokColon {
+		p.next()
+	}
+
+	first := p.peek()
+	var last token
+	count := 0
+	for {
+		t := p.peek()
+		if t.kind == tokNewline || t.kind == tokComment || t.kind == tokSemicolon ||
+			t.kind == tokRBrace || t.kind == tokEOF {
+			break
+		}
+		if t.kind == tokIllegal && t.code != CodeIllegalChar {
+			return nil, p.unexpected(t, "")
+		}
+		if t.kind == tokLBrace {
+			// 通常是漏写了换行或 '}'；只跳过该行剩余的内容，不当作代码块跳过，以免吞掉后面的配置项和块的结束符
+			p.diags = append(p.diags, p.unexpected(t, fmt.Sprintf("%s 的值", entry.Key)).
+				WithHint("值中包含 '{' 时请使用双引号"))
+			for p.peek().kind != tokNewline && p.peek().kind != tokEOF {
+				p.next()
+			}
+			break
+		}
+		last = p.next()
+		count++
+	}
+	if count == 0 {
+		if first.kind == tokLBrace {
+			return entry, nil
+		}
+		return nil, p.unexpected(first, fmt.Sprintf("%s 的值", entry.Key))
+	}
+	entry.ValuePos = first.pos
+	if count == 1 && first.kind == tokString {
+		entry.Value = first.value
+	} else {
+		entry.Value = strings.Trim(strings.TrimSpace(p.src[first.offset:last.end]), `"`)
+	}
+	if _, err := p.endOfLine(); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// parseImportDecl 解析 import "path" 或 import ( ... )
+func (p *parser) parseImportDecl() error {
+	p.next() // import
+	p.takeDoc()
+	if p.peek().kind != tokLParen {
+		spec, err := p.parseImportSpec()
+		if err != nil {
+			return err
+		}
+		p.file.Imports = append(p.file.Imports, spec)
+		return nil
+	}
+
+	open := p.next()
+	for {
+		p.skipBlank()
+		p.doc = nil
+		switch p.peek().kind {
+		case tokRParen:
+			p.closeBlock()
+			return nil
+		case tokEOF:
+			p.diags = append(p.diags, p.unclosed(open, "导入组"))
+			return nil
+		}
+		spec, err := p.parseImportSpec()
+		if err != nil {
+			p.reportAndSkip(err, hintImport)
+			continue
+		}
+		p.file.Imports = append(p.file.Imports, spec)
+	}
+}
+
+// parseImportSpec 解析单个导入路径
+func (p *parser) parseImportSpec() (*ImportSpec, error) {
+	tok := p.peek()
+	if tok.kind != tokString && tok.kind != tokRawString {
+		return nil, p.unexpected(tok, "导入路径字符串")
+	}
+	p.next()
+	if tok.value == "" {
+		return nil, p.errorf(tok.pos, CodeUnexpectedToken, "导入路径不能为空")
+	}
+	if _, err := p.endOfLine(); err != nil {
+		return nil, err
+	}
+	return &ImportSpec{Pos: tok.pos, Path: tok.value}, nil
+}
+
+// parseTypeDecl 解析 type Name {...}、type Name = T 或 type ( ... )
+func (p *parser) parseTypeDecl() error {
+	p.next() // type
+	if p.peek().kind != tokLParen {
+		spec, err := p.parseTypeSpec()
+		if err != nil {
+			return err
+		}
+		p.file.Types = append(p.file.Types, spec)
+		return nil
+	}
+
+	open := p.next()
+	// 类型组本身的注释不附加到组内类型
+	p.takeDoc()
+	for {
+		p.skipBlank()
+		switch p.peek().kind {
+		case tokRParen:
+			p.closeBlock()
+			return nil
+		case tokEOF:
+			p.diags = append(p.diags, p.unclosed(open, "类型组"))
+			return nil
+		}
+		spec, err := p.parseTypeSpec()
+		if err != nil {
+			p.reportAndSkip(err, hintType)
+			continue
+		}
+		p.file.Types = append(p.file.Types, spec)
+	}
+}
+
+// parseTypeSpec 解析单个类型定义（不含 type 关键字）
+func (p *parser) parseTypeSpec() (*TypeSpec, error) {
+	name, err := p.expect(tokIdent, "类型名")
+	if err != nil {
+		return nil, err
+	}
+	spec := &TypeSpec{Pos: name.pos, Name: name.text, Doc: p.takeDoc()}
+
+	// 结构体: Name {  或  Name struct {
+	if p.isKeyword("struct") && p.peekAt(1).kind == tokLBrace {
+		p.next()
+	}
+	if p.peek().kind == tokLBrace {
+		spec.Fields = p.parseStructBody()
+		return spec, nil
+	}
+
+	// 类型别名: Name = T  或  Name T
+	if p.peek().kind == tokAssign {
+		p.next()
+	}
+	aliasTo, _, err := p.parseTypeExpr()
+	if err != nil {
+		return nil, err
+	}
+	spec.IsAlias = true
+	spec.AliasTo = aliasTo
+	spec.Fields = make([]*FieldDecl, 0)
+	comment, err := p.endOfLine()
+	if err != nil {
+		return nil, err
+	}
+	if spec.Doc == "" {
+		spec.Doc = comment
+	}
+	return spec, nil
+}
+
+// parseStructBody 解析 { 字段... }
+func (p *parser) parseStructBody() []*FieldDecl {
+	open := p.next() // {
+	fields := make([]*FieldDecl, 0)
+	for {
+		p.skipBlank()
+		p.doc = nil
+		switch p.peek().kind {
+		case tokRBrace:
+			p.closeBlock()
+			return fields
+		case tokEOF, tokRParen:
+			p.diags = append(p.diags, p.unclosed(open, "结构体"))
+			return fields
+		}
+		field, err := p.parseField()
+		if err != nil {
+			p.reportAndSkip(err, hintField)
+			continue
+		}
+		fields = append(fields, field)
+	}
+}
+
+// parseField 解析字段: Name Type `tag` // comment，或嵌入字段: Type `tag` // comment
+func (p *parser) parseField() (*FieldDecl, error) {
+	tok := p.peek()
+	field := &FieldDecl{Pos: tok.pos}
+
+	embedded := false
+	switch tok.kind {
+	case tokStar:
+		embedded = true
+	case tokIdent:
+		switch p.peekAt(1).kind {
+		case tokNewline, tokSemicolon, tokComment, tokRBrace, tokRawString, tokString, tokDot, tokEOF:
+			embedded = true
+		}
+	default:
+		return nil, p.unexpected(tok, "字段名")
+	}
+
+	if !embedded {
+		p.next()
+		field.Name = tok.text
+	}
+	typ, typePos, err := p.parseTypeExpr()
+	if err != nil {
+		return nil, err
+	}
+	field.Type = typ
+	field.TypePos = typePos
+
+	if t := p.peek(); t.kind == tokRawString || t.kind == tokString {
+		p.next()
+		field.Tag = t.value
+		field.TagPos = t.pos
+	}
+
+	comment, err := p.endOfLine()
+	if err != nil {
+		return nil, err
+	}
+	field.Comment = comment
+	return field, nil
+}
+
+// parseTypeExpr 解析类型表达式，如 int、*User、[]User、map[string]interface{}、time.Time
+func (p *parser) parseTypeExpr() (string, Pos, error) {
+	tok := p.peek()
+	switch tok.kind {
+	case tokStar:
+		p.next()
+		inner, _, err := p.parseTypeExpr()
+		return "*" + inner, tok.pos, err
+	case tokLBrack:
+		p.next()
+		length := ""
+		if p.peek().kind == tokInt {
+			length = p.next().text
+		}
+		if _, err := p.expect(tokRBrack, "']'"); err != nil {
+			return "", tok.pos, err
+		}
+		inner, _, err := p.parseTypeExpr()
+		return "[" + length + "]" + inner, tok.pos, err
+	case tokIdent:
+		p.next()
+		switch tok.text {
+		case "map":
+			if _, err := p.expect(tokLBrack, "'['"); err != nil {
+				return "", tok.pos, err
+			}
+			key, _, err := p.parseTypeExpr()
+			if err != nil {
+				return "", tok.pos, err
+			}
+			if _, err := p.expect(tokRBrack, "']'"); err != nil {
+				return "", tok.pos, err
+			}
+			value, _, err := p.parseTypeExpr()
+			return "map[" + key + "]" + value, tok.pos, err
+		case "interface":
+			if _, err := p.expect(tokLBrace, "'{'"); err != nil {
+				return "", tok.pos, err
+			}
+			if _, err := p.expect(tokRBrace, "'}'"); err != nil {
+				return "", tok.pos, err
+			}
+			return "interface{}", tok.pos, nil
+		case "struct":
+			return "", tok.pos, p.errorf(tok.pos, CodeAnonymousStruct, "不支持匿名结构体")
+		}
+		name := tok.text
+		if p.peek().kind == tokDot {
+			p.next()
+			sel, err := p.expect(tokIdent, "类型名")
+			if err != nil {
+				return "", tok.pos, err
+			}
+			name += "." + sel.text
+		}
+		return name, tok.pos, nil
+	}
+	return "", tok.pos, p.unexpected(tok, "类型")
+}
+
+// parseService 解析 service Name [prefix v1] { ... }
+func (p *parser) parseService() (*ServiceDecl, error) {
+	keyword := p.next() // service
+	name, err := p.expect(tokIdent, "服务名")
+	if err != nil {
+		return nil, err
+	}
+	service := &ServiceDecl{Pos: name.pos, Name: name.text, Doc: p.takeDoc()}
+
+	if p.isKeyword("prefix") {
+		p.next()
+		tok := p.peek()
+		switch tok.kind {
+		case tokIdent:
+			service.Prefix = tok.text
+		case tokString:
+			service.Prefix = strings.TrimPrefix(tok.value, "/")
+		case tokPath:
+			service.Prefix = strings.TrimPrefix(tok.text, "/")
+		default:
+			return nil, p.unexpected(tok, "服务前缀")
+		}
+		p.next()
+	}
+
+	open, err := p.expect(tokLBrace, "'{'")
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipBlank()
+		tok := p.peek()
+		switch {
+		case tok.kind == tokRBrace:
+			p.closeBlock()
+			return service, nil
+		case tok.kind == tokEOF:
+			p.diags = append(p.diags, p.unclosed(open, keyword.text+" "+service.Name))
+			return service, nil
+		case tok.kind == tokAt:
+			method, err := p.parseMethod()
+			if err != nil {
+				p.reportAndSkip(err, hintMethod)
+				continue
+			}
+			service.Methods = append(service.Methods, method)
+		case tok.kind == tokIdent && tok.text == "middleware":
+			p.doc = nil
+			entries, err := p.parseMiddlewareStmt()
+			if err != nil {
+				p.reportAndSkip(err, hintMiddleware)
+				continue
+			}
+			for _, entry := range entries {
+				if entry.Skip {
+					p.diags.Warnf(entry.Pos, CodeUselessSkip, "服务级中间件中的 -%s 没有可跳过的上级中间件，已忽略", entry.Name)
+				}
+			}
+			service.Middleware = append(service.Middleware, entries...)
+		case tok.kind == tokIdent && tok.text == "group":
+			group, err := p.parseGroup()
+			if err != nil {
+				p.reportAndSkip(err, hintGroup)
+				continue
+			}
+			service.Groups = append(service.Groups, group)
+		default:
+			p.reportAndSkip(p.unexpected(tok, "middleware、group、@方法 或 '}'"), hintMethod)
+		}
+	}
+}
+
+// parseGroup 解析 group [@name] /path { ... }
+func (p *parser) parseGroup() (*GroupDecl, error) {
+	keyword := p.next() // group
+	group := &GroupDecl{Pos: keyword.pos, Doc: p.takeDoc()}
+
+	if p.peek().kind == tokAt {
+		p.next()
+		name, err := p.expect(tokIdent, "分组名")
+		if err != nil {
+			return nil, err
+		}
+		group.Name = name.text
+	} else if p.peek().kind == tokIdent {
+		group.Name = p.next().text
+	}
+
+	path := p.peek()
+	switch path.kind {
+	case tokPath:
+		group.Path = path.text
+	case tokString:
+		group.Path = path.value
+	default:
+		return nil, p.unexpected(path, "分组路径")
+	}
+	p.next()
+	group.PathPos = path.pos
+	if group.Name == "" {
+		group.Name = strings.ReplaceAll(strings.TrimPrefix(group.Path, "/"), "/", "")
+	}
+
+	open, err := p.expect(tokLBrace, "'{'")
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipBlank()
+		tok := p.peek()
+		switch {
+		case tok.kind == tokRBrace:
+			p.closeBlock()
+			return group, nil
+		case tok.kind == tokEOF:
+			p.diags = append(p.diags, p.unclosed(open, "分组 "+group.Name))
+			return group, nil
+		case tok.kind == tokAt:
+			method, err := p.parseMethod()
+			if err != nil {
+				p.reportAndSkip(err, hintMethod)
+				continue
+			}
+			group.Methods = append(group.Methods, method)
+		case tok.kind == tokIdent && (tok.text == "middleware" || tok.text == "skip"):
+			p.doc = nil
+			entries, err := p.parseMiddlewareStmt()
+			if err != nil {
+				p.reportAndSkip(err, hintMiddleware)
+				continue
+			}
+			group.Middleware = append(group.Middleware, entries...)
+		case tok.kind == tokIdent && tok.text == "group":
+			child, err := p.parseGroup()
+			if err != nil {
+				p.reportAndSkip(err, hintGroup)
+				continue
+			}
+			group.Groups = append(group.Groups, child)
+		default:
+			p.reportAndSkip(p.unexpected(tok, "middleware、skip、group、@方法 或 '}'"), hintMethod)
+		}
+	}
+}
+
+// parseMiddlewareStmt 解析独占一行的 middleware: [...] 或 skip: [...]
+func (p *parser) parseMiddlewareStmt() ([]*MiddlewareEntry, error) {
+	entries, err := p.parseMiddlewareList()
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.endOfLine()
+	return entries, err
+}
+
+// parseMiddlewareList 解析 middleware: ["a", "-b"] 或 skip: ["b"]
+func (p *parser) parseMiddlewareList() ([]*MiddlewareEntry, error) {
+	keyword := p.next() // middleware 或 skip
+	if _, err := p.expect(tokColon, "':'"); err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(tokLBrack, "'['"); err != nil {
+		return nil, err
+	}
+	entries := make([]*MiddlewareEntry, 0)
+	for {
+		p.skipNewlines()
+		tok := p.peek()
+		entry := &MiddlewareEntry{Pos: tok.pos, Skip: keyword.text == "skip"}
+		minus := tok.kind == tokMinus
+		if minus {
+			// middleware: [-auth]
+			entry.Skip = true
+			p.next()
+			tok = p.peek()
+		}
+		switch tok.kind {
+		case tokRBrack:
+			if minus {
+				return nil, p.unexpected(tok, "中间件名称")
+			}
+			p.next()
+			return entries, nil
+		case tokString:
+			entry.Name = tok.value
+		case tokIdent:
+			entry.Name = tok.text
+		default:
+			return nil, p.unexpected(tok, "中间件名称或 ']'")
+		}
+		if strings.HasPrefix(entry.Name, "-") {
+			// middleware: ["-auth"]
+			entry.Skip = true
+			entry.Name = entry.Name[1:]
+		}
+		if entry.Name == "" {
+			return nil, p.unexpected(tok, "中间件名称")
+		}
+		entries = append(entries, entry)
+		p.next()
+		p.skipNewlines()
+		if p.peek().kind == tokComma {
+			p.next()
+		} else if p.peek().kind != tokRBrack {
+			return nil, p.unexpected(p.peek(), "',' 或 ']'")
+		}
+	}
+}
+
+// parseMethod 解析 @name METHOD /path [WithGinContext] Request Response [status:201] [middleware: [...]] [skip: [...]] // comment
+func (p *parser) parseMethod() (*MethodDecl, error) {
+	at := p.next() // @
+	method := &MethodDecl{Pos: at.pos, Doc: p.takeDoc()}
+
+	name, err := p.expect(tokIdent, "方法名")
+	if err != nil {
+		return nil, err
+	}
+	method.Name = name.text
+
+	httpMethod, err := p.expect(tokIdent, "HTTP 方法")
+	if err != nil {
+		return nil, err
+	}
+	method.HTTPMethod = strings.ToUpper(httpMethod.text)
+	if !httpMethods[method.HTTPMethod] {
+		return nil, p.errorf(httpMethod.pos, CodeUnknownHTTPMethod, "不支持的 HTTP 方法: %s", httpMethod.text)
+	}
+
+	path := p.peek()
+	switch path.kind {
+	case tokPath:
+		method.Path = path.text
+	case tokString:
+		method.Path = path.value
+	default:
+		return nil, p.unexpected(path, "路由路径")
+	}
+	p.next()
+	method.PathPos = path.pos
+
+	if p.isKeyword("WithGinContext") {
+		p.next()
+		method.WithGinContext = true
+	}
+
+	if method.Request, method.RequestPos, err = p.parseMessageType("请求类型"); err != nil {
+		return nil, err
+	}
+	if method.Response, method.ResponsePos, err = p.parseMessageType("响应类型"); err != nil {
+		return nil, err
+	}
+
+	method.Middleware = make([]*MiddlewareEntry, 0)
+	for p.isKeyword("middleware") || p.isKeyword("skip") || p.isKeyword("status") {
+		if p.isKeyword("status") {
+			if err := p.parseStatus(method); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		entries, err := p.parseMiddlewareList()
+		if err != nil {
+			return nil, err
+		}
+		method.Middleware = append(method.Middleware, entries...)
+	}
+
+	comment, err := p.endOfLine()
+	if err != nil {
+		return nil, err
+	}
+	method.Comment = comment
+	return method, nil
+}
+
+// parseMessageType 解析请求或响应类型，- 和 Empty 表示没有请求体或响应体，返回空的类型名
+func (p *parser) parseMessageType(expected string) (string, Pos, error) {
+	tok := p.peek()
+	switch {
+	case tok.kind == tokMinus:
+		p.next()
+		return "", tok.pos, nil
+	case tok.kind == tokIdent && tok.text == EmptyMessage:
+		p.next()
+		return "", tok.pos, nil
+	case tok.kind == tokIdent:
+		p.next()
+		return tok.text, tok.pos, nil
+	}
+	return "", tok.pos, p.unexpected(tok, expected+"或 -")
+}
+
+// parseStatus 解析 status:201，成功响应的状态码必须为 2xx
+func (p *parser) parseStatus(method *MethodDecl) error {
+	p.next() // status
+	if _, err := p.expect(tokColon, "':'"); err != nil {
+		return err
+	}
+	tok, err := p.expect(tokInt, "状态码")
+	if err != nil {
+		return err
+	}
+	status, err := strconv.Atoi(tok.text)
+	if err != nil || status < 200 || status > 299 {
+		return p.errorf(tok.pos, CodeInvalidStatus, "无效的成功状态码: %s", tok.text).
+			WithHint("status 只能使用 2xx 状态码，例如 status:201 或 status:204")
+	}
+	method.Status = status
+	method.StatusPos = tok.pos
+	return nil
+}
@@ -0,0 +1,159 @@
+package parser
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// declNames 按出现顺序列出语法树中解析成功的声明，用于检查错误恢复
+func declNames(file *File) []string {
+	var names []string
+	for _, e := range file.Enums {
+		names = append(names, "enum "+e.Name)
+	}
+	for _, t := range file.Types {
+		fields := make([]string, 0, len(t.Fields))
+		for _, f := range t.Fields {
+			fields = append(fields, f.Name)
+		}
+		names = append(names, "type "+t.Name+"{"+strings.Join(fields, ",")+"}")
+	}
+	var methods func(prefix string, list []*MethodDecl, groups []*GroupDecl)
+	methods = func(prefix string, list []*MethodDecl, groups []*GroupDecl) {
+		for _, m := range list {
+			names = append(names, prefix+"@"+m.Name)
+		}
+		for _, g := range groups {
+			methods(prefix+"group "+g.Path+" ", g.Methods, g.Groups)
+		}
+	}
+	for _, s := range file.Services {
+		methods("service "+s.Name+" ", s.Methods, s.Groups)
+	}
+	methods("", file.Routes, file.Groups)
+	return names
+}
+
+func TestParseFile(t *testing.T) {
+	tests := []struct {
+		name  string
+		src   string
+		diags []string // 行:列 代码
+		decls []string
+	}{
+		{
+			name: "完整的文件",
+			src: `options {
+	packageName: v1
+}
+enum Status int { Active = 1; Disabled = 2 }
+type (
+	Req {
+		ID     int ` + "`uri:\"id\"`" + `
+		Status Status
+	}
+	Resp = Req
+)
+service S prefix v1 {
+	@Get GET /items/:id Req Resp status:200
+	group @admin /admin {
+		group /sub {
+			@List GET /list - -
+		}
+	}
+}
+@Ping GET /ping - -
+`,
+			decls: []string{
+				"enum Status", "type Req{ID,Status}", "type Resp{}",
+				"service S @Get", "service S group /admin group /sub @List", "@Ping",
+			},
+		},
+		{
+			name: "字段错误后继续解析后续字段和类型",
+			src: `type A {
+	ID int
+	Bad [int
+	Name string
+}
+type B {
+	X int
+}
+`,
+			diags: []string{"3:7 E0003"},
+			decls: []string{"type A{ID,Name}", "type B{X}"},
+		},
+		{
+			name: "方法错误后继续解析后续方法",
+			src: `service S {
+	@Get FETCH /a - -
+	@List GET /b - - status:404
+	@Create POST /c Req
+	@Delete DELETE /d - -
+}
+`,
+			diags: []string{"2:7 E0004", "3:26 E0010", "4:21 E0003"},
+			decls: []string{"service S @Delete"},
+		},
+		{
+			name: "非法字符和未闭合的字符串",
+			src: `info {
+	title: "abc
+}
+type A {
+	ID # int
+}
+@Ping GET /ping - -
+`,
+			diags: []string{"2:9 E0002", "5:5 E0001"},
+			decls: []string{"type A{}", "@Ping"},
+		},
+		{
+			name: "未闭合的代码块",
+			src: `service S {
+	@Get GET /a - -
+`,
+			diags: []string{"3:1 E0003"},
+			decls: []string{"service S @Get"},
+		},
+		{
+			name: "配置项的值中出现 '{'",
+			src: `options { packageName: v1 {
+	outputDir: .
+}
+type A {
+	ID int
+}
+@Ping GET /ping - -
+`,
+			diags: []string{"1:27 E0003"},
+			decls: []string{"type A{ID}", "@Ping"},
+		},
+		{
+			name: "多余的右括号和未知的声明",
+			src: `}
+foo bar
+@Ping GET /ping - -
+`,
+			diags: []string{"1:1 E0003", "2:1 E0003"},
+			decls: []string{"@Ping"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, diags := ParseFile("test.gin", tt.src)
+			var got []string
+			for _, d := range diags {
+				got = append(got, fmt.Sprintf("%d:%d %s", d.Pos.Line, d.Pos.Column, d.Code))
+			}
+			if !reflect.DeepEqual(got, tt.diags) {
+				t.Errorf("诊断 = %q，期望 %q\n%v", got, tt.diags, diags)
+			}
+			if decls := declNames(file); !reflect.DeepEqual(decls, tt.decls) {
+				t.Errorf("声明 = %q，期望 %q", decls, tt.decls)
+			}
+		})
+	}
+}
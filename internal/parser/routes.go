@@ -0,0 +1,68 @@
+package parser
+
+import (
+	"path"
+	"strings"
+)
+
+// Route 表示展开后的一条路由
+type Route struct {
+	Method
+	Service  string   // 所属的服务接口名：服务名、<Group>Service 或 StandaloneService
+	Groups   []string // 服务内从外到内的分组名，顶级分组本身对应服务，不包含在内
+	FullPath string   // 包含服务前缀和分组路径的完整路径
+}
+
+// Routes 按定义顺序返回模板中的全部路由
+func (t *GinTemplate) Routes() []Route {
+	var routes []Route
+
+	var addGroup func(service string, groups []string, base string, group RouteGroup)
+	addGroup = func(service string, groups []string, base string, group RouteGroup) {
+		for _, method := range group.Methods {
+			routes = append(routes, Route{Method: method, Service: service, Groups: groups, FullPath: JoinPath(base, method.Path)})
+		}
+		for _, child := range group.Groups {
+			childGroups := append(append([]string(nil), groups...), child.Name)
+			addGroup(service, childGroups, JoinPath(base, child.Path), child)
+		}
+	}
+
+	for _, service := range t.Services {
+		base := "/"
+		if service.Prefix != "" {
+			base = JoinPath("/", service.Prefix)
+		}
+		addGroup(service.Name, nil, base, RouteGroup{Methods: service.Methods, Groups: service.RouteGroups})
+	}
+	for _, group := range t.RouteGroups {
+		addGroup(GroupServiceName(group.Name), nil, JoinPath("/", group.Path), group)
+	}
+	for _, route := range t.StandaloneRoutes {
+		routes = append(routes, Route{Method: route.Method, Service: StandaloneServiceName, FullPath: JoinPath("/", route.Path)})
+	}
+	return routes
+}
+
+// JoinPath 按 gin 的规则拼接路由组路径和相对路径，保留相对路径末尾的 /
+func JoinPath(absolute, relative string) string {
+	if relative == "" {
+		return absolute
+	}
+	final := path.Join(absolute, relative)
+	if strings.HasSuffix(relative, "/") && !strings.HasSuffix(final, "/") {
+		return final + "/"
+	}
+	return final
+}
+
+// PathParams 返回路径中的参数名，包括 :name 和 *name
+func PathParams(fullPath string) []string {
+	var params []string
+	for _, segment := range strings.Split(fullPath, "/") {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			params = append(params, segment[1:])
+		}
+	}
+	return params
+}
@@ -0,0 +1,165 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+)
+
+// 校验规则类型
+const (
+	ValidatorKindRegex    = "regex"    // 按正则表达式校验字段值
+	ValidatorKindExternal = "external" // 在代码中手动注册，只声明规则名和错误信息
+)
+
+// Validator 表示 validators 块中声明的自定义校验规则，生成到 validators.go 的 RegisterValidators 中
+type Validator struct {
+	Pos        Pos
+	Name       string // binding 标签中使用的规则名
+	Kind       string
+	KindPos    Pos
+	Pattern    string
+	PatternPos Pos
+	Messages   []ValidatorMessage // 按语言区分的错误信息，{0} 为字段名
+	Comment    string
+}
+
+// parseValidators 解析 validators { ... }，每行声明一条校验规则
+func (p *parser) parseValidators() error {
+	keyword := p.next() // validators
+	p.takeDoc()
+	open, err := p.expect(tokLBrace, "'{'")
+	if err != nil {
+		return err
+	}
+	for {
+		p.skipBlank()
+		switch p.peek().kind {
+		case tokRBrace:
+			p.closeBlock()
+			return nil
+		case tokEOF:
+			p.diags = append(p.diags, p.unclosed(open, keyword.text+" 块"))
+			return nil
+		}
+		decl, err := p.parseValidator()
+		if err != nil {
+			p.reportAndSkip(err, hintValidator)
+			continue
+		}
+		p.file.Validators = append(p.file.Validators, decl)
+	}
+}
+
+// parseValidator 解析 phone regex "^1[3-9]\\d{9}$" zh: "{0}必须是有效的手机号" // 注释
+// 正则表达式可以使用双引号或反引号字符串，external 规则没有正则表达式，错误信息以语言为键，行尾注释优先作为规则说明
+func (p *parser) parseValidator() (*ValidatorDecl, error) {
+	doc := p.takeDoc()
+	name, err := p.expect(tokIdent, "校验规则名或 '}'")
+	if err != nil {
+		return nil, err
+	}
+	kind, err := p.expect(tokIdent, "规则类型")
+	if err != nil {
+		return nil, err
+	}
+	decl := &ValidatorDecl{Pos: name.pos, Name: name.text, Kind: kind.text, KindPos: kind.pos}
+
+	if pattern := p.peek(); pattern.kind == tokString || pattern.kind == tokRawString {
+		p.next()
+		decl.Pattern = pattern.value
+		decl.PatternPos = pattern.pos
+	}
+
+	for p.peek().kind == tokIdent {
+		locale := p.next()
+		if _, err := p.expect(tokColon, "':'"); err != nil {
+			return nil, err
+		}
+		text, err := p.expect(tokString, "错误信息字符串")
+		if err != nil {
+			return nil, err
+		}
+		decl.Messages = append(decl.Messages, ValidatorMessage{Pos: locale.pos, Locale: locale.text, Text: text.value})
+	}
+
+	comment, err := p.endOfLine()
+	if err != nil {
+		return nil, err
+	}
+	decl.Comment = comment
+	if decl.Comment == "" {
+		decl.Comment = doc
+	}
+	return decl, nil
+}
+
+func buildValidator(decl *ValidatorDecl) Validator {
+	return Validator{
+		Pos:        decl.Pos,
+		Name:       decl.Name,
+		Kind:       decl.Kind,
+		KindPos:    decl.KindPos,
+		Pattern:    decl.Pattern,
+		PatternPos: decl.PatternPos,
+		Messages:   append([]ValidatorMessage(nil), decl.Messages...),
+		Comment:    decl.Comment,
+	}
+}
+
+// BindingRules 返回字段 binding 标签中使用的校验规则名，不包含 = 后的参数，以 | 连接的规则分别返回
+func (f Field) BindingRules() []string {
+	tag := reflect.StructTag(f.Tag).Get("binding")
+	if tag == "" {
+		return nil
+	}
+	var rules []string
+	for _, part := range strings.Split(tag, ",") {
+		for _, rule := range strings.Split(part, "|") {
+			name, _, _ := strings.Cut(rule, "=")
+			if name = strings.TrimSpace(name); name != "" {
+				rules = append(rules, name)
+			}
+		}
+	}
+	return rules
+}
+
+// RequestTypes 返回方法中用作请求类型的结构体，别名解析为其指向的结构体，按定义顺序排列
+// validate: true 时为这些类型生成 Validate 方法
+func (t *GinTemplate) RequestTypes() []Type {
+	types := make(map[string]Type, len(t.Types))
+	for _, typ := range t.Types {
+		if _, ok := types[typ.Name]; !ok {
+			types[typ.Name] = typ
+		}
+	}
+
+	used := make(map[string]bool)
+	for _, service := range t.AllServices() {
+		for _, method := range service.AllMethods() {
+			name := method.Request
+			seen := make(map[string]bool)
+			for !seen[name] {
+				seen[name] = true
+				typ, ok := types[name]
+				if !ok {
+					break
+				}
+				if !typ.IsAlias {
+					used[name] = true
+					break
+				}
+				name = typ.AliasTo
+			}
+		}
+	}
+
+	var result []Type
+	for _, typ := range t.Types {
+		if used[typ.Name] {
+			result = append(result, typ)
+			delete(used, typ.Name)
+		}
+	}
+	return result
+}
@@ -19,6 +19,8 @@ func init() {
 	rootCmd.AddCommand(cli.GenCommand())
 	rootCmd.AddCommand(cli.NewCommand())
 	rootCmd.AddCommand(cli.FormatCommand())
+	rootCmd.AddCommand(cli.OpenAPICommand())
+	rootCmd.AddCommand(cli.ClientCommand())
 }
 
 func main() {